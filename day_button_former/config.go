@@ -12,8 +12,18 @@ type FlatConfig struct {
 	PostfixForNonSelectedDay   string
 	PrefixForPickDay           string
 	PostfixForPickDay          string
+	PrefixForAdjacentDay       string
+	PostfixForAdjacentDay      string
+	PrefixForPreselectedDay    string
+	PostfixForPreselectedDay   string
 	UnselectableDaysBeforeTime time.Time
 	UnselectableDaysAfterTime  time.Time
 	UnselectableDays           map[time.Time]struct{}
 	Timezone                   time.Location
+	NumeralSystem              NumeralSystem
+	MinimumLeadTime            time.Duration
+	DayDecorators              []DayDecorator
+	LoadProvider               LoadProvider
+	LoadLevels                 []LoadLevel
+	FullLoadThreshold          float64
 }
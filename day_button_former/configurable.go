@@ -0,0 +1,148 @@
+package day_button_former
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// DaysButtonsTextWrapper is a decorator of another DaysButtonsText. The options are applied to the wrapped one,
+// so they work through any number of decorators.
+type DaysButtonsTextWrapper interface {
+	DaysButtonsText
+	Unwrap() DaysButtonsText
+	// WithWrapped returns the same decorator around wrapped, the receiver is not changed.
+	WithWrapped(wrapped DaysButtonsText) DaysButtonsText
+}
+
+// ConfigurableDaysButtonsText is an own DaysButtonsText that keeps the FlatConfig settings (from GetCurrentConfig),
+// the options change them.
+type ConfigurableDaysButtonsText interface {
+	DaysButtonsText
+	// WithConfig returns the copy with the settings, the receiver is not changed.
+	WithConfig(config FlatConfig) DaysButtonsText
+}
+
+// ErrUnsupportedOption the option does nothing to the DaysButtonsText implementation.
+var ErrUnsupportedOption = errors.New("option is not supported")
+
+// UnsupportedOptionError the option at Index does nothing, see CheckOptions.
+type UnsupportedOptionError struct {
+	Index int
+	Err   error
+}
+
+func (e *UnsupportedOptionError) Error() string {
+	return "option " + strconv.Itoa(e.Index) + ": " + e.Err.Error()
+}
+
+func (e *UnsupportedOptionError) Unwrap() error {
+	return e.Err
+}
+
+// NewButtonsFormerFromConfig the former with the settings, GetCurrentConfig returns them back.
+// ConfigurableDaysButtonsText implementations may keep one to render the days.
+func NewButtonsFormerFromConfig(config FlatConfig) DaysButtonsText {
+	return newButtonsFormerFromConfig(config)
+}
+
+func newButtonsFormerFromConfig(config FlatConfig) *DayButtonFormer {
+	timezone := config.Timezone
+	bf := &DayButtonFormer{
+		buttons: buttonsData{
+			prefixForCurrentDay:      config.PrefixForCurrentDay,
+			postfixForCurrentDay:     config.PostfixForCurrentDay,
+			prefixForNonSelectedDay:  config.PrefixForNonSelectedDay,
+			postfixForNonSelectedDay: config.PostfixForNonSelectedDay,
+			prefixForPickDay:         config.PrefixForPickDay,
+			postfixForPickDay:        config.PostfixForPickDay,
+			prefixForAdjacentDay:     config.PrefixForAdjacentDay,
+			postfixForAdjacentDay:    config.PostfixForAdjacentDay,
+			prefixForPreselectedDay:  config.PrefixForPreselectedDay,
+			postfixForPreselectedDay: config.PostfixForPreselectedDay,
+		},
+		unselectableDaysBeforeTime: config.UnselectableDaysBeforeTime.In(&timezone),
+		unselectableDaysAfterTime:  config.UnselectableDaysAfterTime.In(&timezone),
+		unselectableDays:           make(map[time.Time]struct{}, len(config.UnselectableDays)),
+		timezone:                   &timezone,
+		numeralSystem:              config.NumeralSystem,
+		minimumLeadTime:            config.MinimumLeadTime,
+		dayDecorators:              append([]DayDecorator(nil), config.DayDecorators...),
+		loadProvider:               config.LoadProvider,
+		loadLevels:                 append([]LoadLevel(nil), config.LoadLevels...),
+		fullLoadThreshold:          config.FullLoadThreshold,
+	}
+	// The days are map keys, they must be at the same location the lookups use.
+	for day := range config.UnselectableDays {
+		bf.unselectableDays[day.In(&timezone)] = struct{}{}
+	}
+	if bf.minimumLeadTime < 0 {
+		bf.minimumLeadTime = 0
+	}
+	return bf
+}
+
+// ApplyOptions applies the options to bf one by one, own implementations may use it for ApplyNewOptions.
+// Like ApplyNewOptions of DayButtonFormer, bf itself is not changed.
+func ApplyOptions(bf DaysButtonsText, options ...func(DaysButtonsText) DaysButtonsText) DaysButtonsText {
+	if dbf, ok := bf.(*DayButtonFormer); ok {
+		return dbf.ApplyNewOptions(options...)
+	}
+	for _, option := range options {
+		bf = option(bf)
+	}
+	return bf
+}
+
+// CheckOptions reports the options that would do nothing to bf, each one as *UnsupportedOptionError.
+// The options of this package support *DayButtonFormer, DaysButtonsTextWrapper and ConfigurableDaysButtonsText.
+// bf is not changed. Options of other packages are not checked.
+func CheckOptions(bf DaysButtonsText, options ...func(DaysButtonsText) DaysButtonsText) error {
+	var errs []error
+	for i, option := range options {
+		probe := &optionsProbe{DaysButtonsText: bf}
+		option(probe)
+		if probe.unsupported != nil {
+			errs = append(errs, &UnsupportedOptionError{Index: i, Err: probe.unsupported})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Wraps the checked DaysButtonsText, the options are applied to a copy and the unsupported one is recorded.
+type optionsProbe struct {
+	DaysButtonsText
+	unsupported error
+}
+
+// Applies the option to bf: *DayButtonFormer is changed in place (options get a copy from ApplyNewOptions),
+// the wrapped ones are copied first, because the decorator shares them.
+func configure(bf DaysButtonsText, apply func(dbf *DayButtonFormer)) DaysButtonsText {
+	return configureWith(bf, apply, false, nil)
+}
+
+func configureWith(bf DaysButtonsText, apply func(dbf *DayButtonFormer), copyFormer bool, unsupported *error) DaysButtonsText {
+	switch dbf := bf.(type) {
+	case *DayButtonFormer:
+		if copyFormer {
+			dbf = dbf.clone()
+		}
+		apply(dbf)
+		return dbf
+	case *optionsProbe:
+		dbf.DaysButtonsText = configureWith(dbf.DaysButtonsText, apply, true, &dbf.unsupported)
+		return dbf
+	case DaysButtonsTextWrapper:
+		return dbf.WithWrapped(configureWith(dbf.Unwrap(), apply, true, unsupported))
+	case ConfigurableDaysButtonsText:
+		former := newButtonsFormerFromConfig(dbf.GetCurrentConfig())
+		apply(former)
+		return dbf.WithConfig(former.GetCurrentConfig())
+	default:
+		if unsupported != nil {
+			*unsupported = fmt.Errorf("%w by %T", ErrUnsupportedOption, bf)
+		}
+		return bf
+	}
+}
@@ -0,0 +1,121 @@
+package day_button_former
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Decorator that adds a mark to every day.
+type markedDaysButtonsText struct {
+	DaysButtonsText
+	mark string
+}
+
+func (m markedDaysButtonsText) DayButtonTextWrapperWithParams(
+	incomeDay, incomeMonth, incomeYear int,
+	currentTime time.Time,
+	params DayButtonParams,
+) (string, bool) {
+	text, isUnselectable := DayButtonTextWithParams(m.DaysButtonsText, incomeDay, incomeMonth, incomeYear, currentTime, params)
+	return m.mark + text, isUnselectable
+}
+
+func (m markedDaysButtonsText) ApplyNewOptions(options ...func(DaysButtonsText) DaysButtonsText) DaysButtonsText {
+	return ApplyOptions(m, options...)
+}
+
+func (m markedDaysButtonsText) Unwrap() DaysButtonsText {
+	return m.DaysButtonsText
+}
+
+func (m markedDaysButtonsText) WithWrapped(wrapped DaysButtonsText) DaysButtonsText {
+	m.DaysButtonsText = wrapped
+	return m
+}
+
+// Own former that keeps the settings.
+type configDaysButtonsText struct {
+	DaysButtonsText
+}
+
+func (c configDaysButtonsText) ApplyNewOptions(options ...func(DaysButtonsText) DaysButtonsText) DaysButtonsText {
+	return ApplyOptions(c, options...)
+}
+
+func (c configDaysButtonsText) WithConfig(config FlatConfig) DaysButtonsText {
+	return configDaysButtonsText{DaysButtonsText: NewButtonsFormerFromConfig(config)}
+}
+
+// Neither a wrapper nor configurable.
+type opaqueDaysButtonsText struct {
+	DaysButtonsText
+}
+
+func TestOptionsForOwnDaysButtonsText(t *testing.T) {
+	t.Parallel()
+
+	currentTime := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	options := []func(DaysButtonsText) DaysButtonsText{
+		ChangePostfixForNonSelectedDay("🚫"),
+		ChangeUnselectableDays(map[time.Time]struct{}{time.Date(2023, 6, 12, 0, 0, 0, 0, time.UTC): {}}),
+	}
+
+	tests := []struct {
+		name  string
+		bf    DaysButtonsText
+		wantT string
+	}{
+		{
+			name:  "wrapper",
+			bf:    markedDaysButtonsText{DaysButtonsText: NewButtonsFormer(), mark: "·"},
+			wantT: "·12🚫",
+		},
+		{
+			name:  "configurable",
+			bf:    configDaysButtonsText{DaysButtonsText: NewButtonsFormer()},
+			wantT: "12🚫",
+		},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := CheckOptions(tt.bf, options...); err != nil {
+				t.Errorf("unexpected CheckOptions error: %v", err)
+			}
+
+			before := tt.bf.GetCurrentConfig()
+			changed := tt.bf.ApplyNewOptions(options...)
+			if reflect.TypeOf(changed) != reflect.TypeOf(tt.bf) {
+				t.Errorf("former type is changed: got %T, want %T", changed, tt.bf)
+			}
+			if after := tt.bf.GetCurrentConfig(); !reflect.DeepEqual(after, before) {
+				t.Errorf("source former is changed: %+v", after)
+			}
+
+			text, isUnselectable := DayButtonTextWithParams(changed, 12, 6, 2023, currentTime, DayButtonParams{})
+			if text != tt.wantT || !isUnselectable {
+				t.Errorf("unexpected day: got %v %v, want %v true", text, isUnselectable, tt.wantT)
+			}
+		},
+		)
+	}
+}
+
+func TestCheckOptionsReportsUnsupportedDaysButtonsText(t *testing.T) {
+	t.Parallel()
+
+	err := CheckOptions(opaqueDaysButtonsText{DaysButtonsText: NewButtonsFormer()}, ChangePrefixForPickDay(">"))
+	var optionErr *UnsupportedOptionError
+	if !errors.As(err, &optionErr) || optionErr.Index != 0 || !errors.Is(err, ErrUnsupportedOption) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	bf := NewButtonsFormerFromConfig(NewButtonsFormer(ChangePrefixForPickDay(">")).GetCurrentConfig())
+	if got := bf.GetCurrentConfig().PrefixForPickDay; got != ">" {
+		t.Errorf("unexpected prefix from config: %v", got)
+	}
+}
@@ -0,0 +1,12 @@
+package day_button_former
+
+const (
+	fullLoadThresholdDefault = 1
+)
+
+var loadLevelsDefault = []LoadLevel{ //nolint:gochecknoglobals
+	{From: 0, Marker: "🟢"},
+	{From: 0.5, Marker: "🟡"},
+	{From: 0.75, Marker: "🟠"},
+	{From: 1, Marker: "🔴"},
+}
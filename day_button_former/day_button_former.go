@@ -1,8 +1,7 @@
 package day_button_former
 
 import (
-	"strconv"
-	"strings"
+	"context"
 	"time"
 )
 
@@ -15,6 +14,45 @@ type DaysButtonsText interface {
 	GetTimezone() time.Location
 }
 
+// ParamsDaysButtonsText DaysButtonsText that takes the per-render parameters (see DayButtonParams).
+type ParamsDaysButtonsText interface {
+	DaysButtonsText
+	DayButtonTextWrapperWithParams(
+		incomeDay, incomeMonth, incomeYear int,
+		currentTime time.Time,
+		params DayButtonParams,
+	) (string, bool)
+}
+
+// DayButtonTextWithParams forms the text by ParamsDaysButtonsText, other formers get DayButtonTextWrapper
+// without the params, the events marker is added after its text.
+func DayButtonTextWithParams(
+	bt DaysButtonsText,
+	incomeDay, incomeMonth, incomeYear int,
+	currentTime time.Time,
+	params DayButtonParams,
+) (string, bool) {
+	if pbt, ok := bt.(ParamsDaysButtonsText); ok {
+		return pbt.DayButtonTextWrapperWithParams(incomeDay, incomeMonth, incomeYear, currentTime, params)
+	}
+	text, isUnselectableDay := bt.DayButtonTextWrapper(incomeDay, incomeMonth, incomeYear, currentTime)
+	return text + params.EventsMarker, isUnselectableDay
+}
+
+// ContextDaysButtonsText DaysButtonsText that passes the render context to the providers (see ContextLoadProvider).
+// The context may be passed with DayButtonParams.Context as well, so decorators that pass the params through keep it.
+type ContextDaysButtonsText interface {
+	DaysButtonsText
+	// DayButtonTextWrapperContext returns the error of the providers lookups, ctx.Err() if the context is done:
+	// the text is built without the lookups that failed or were not made, such days are unselectable.
+	DayButtonTextWrapperContext(
+		ctx context.Context,
+		incomeDay, incomeMonth, incomeYear int,
+		currentTime time.Time,
+		params DayButtonParams,
+	) (string, bool, error)
+}
+
 // DayButtonFormer ...
 type DayButtonFormer struct {
 	buttons                    buttonsData
@@ -22,20 +60,40 @@ type DayButtonFormer struct {
 	unselectableDaysAfterTime  time.Time
 	unselectableDays           map[time.Time]struct{}
 	timezone                   *time.Location
+	numeralSystem              NumeralSystem
+	minimumLeadTime            time.Duration
+	dayDecorators              []DayDecorator
+	loadProvider               LoadProvider
+	loadLevels                 []LoadLevel
+	fullLoadThreshold          float64
 }
 
-type buttonsData struct {
-	prefixForCurrentDay      extraButtonInfo
-	postfixForCurrentDay     extraButtonInfo
-	prefixForNonSelectedDay  extraButtonInfo
-	postfixForNonSelectedDay extraButtonInfo
-	prefixForPickDay         extraButtonInfo
-	postfixForPickDay        extraButtonInfo
+// DayButtonParams contains per-render parameters of the day button.
+type DayButtonParams struct {
+	// UserLocation is used to find the user's "today", nil means the former timezone.
+	// Unselectable days rules are always evaluated in the former timezone.
+	UserLocation *time.Location
+	// IsAdjacentMonth the day is from the previous or the next month and fills the first or the last week.
+	IsAdjacentMonth bool
+	// PreselectedDate the user's previous choice, zero means no choice. Its own date is used, without conversion.
+	PreselectedDate time.Time
+	// Context the render context for the providers, nil means context.Background().
+	Context context.Context
+	// EventsMarker the events marker of the day ("•" or "•3"), empty means no events.
+	EventsMarker string
 }
 
-type extraButtonInfo struct {
-	value   string
-	growLen int
+type buttonsData struct {
+	prefixForCurrentDay      string
+	postfixForCurrentDay     string
+	prefixForNonSelectedDay  string
+	postfixForNonSelectedDay string
+	prefixForPickDay         string
+	postfixForPickDay        string
+	prefixForAdjacentDay     string
+	postfixForAdjacentDay    string
+	prefixForPreselectedDay  string
+	postfixForPreselectedDay string
 }
 
 // NewButtonsFormer ...
@@ -48,79 +106,99 @@ func NewButtonsFormer(
 func newDefaultButtonsFormer() *DayButtonFormer {
 	return &DayButtonFormer{
 		buttons: buttonsData{
-			prefixForCurrentDay: extraButtonInfo{
-				value:   "",
-				growLen: 0,
-			},
-			postfixForCurrentDay: extraButtonInfo{
-				value:   "🗓",
-				growLen: len("🗓"),
-			},
-			postfixForNonSelectedDay: extraButtonInfo{
-				value:   "❌",
-				growLen: len("❌"),
-			},
+			prefixForCurrentDay:      "",
+			postfixForCurrentDay:     "🗓",
+			postfixForNonSelectedDay: "❌",
+			prefixForAdjacentDay:     "·",
+			postfixForPreselectedDay: "✅",
 		},
 		unselectableDaysBeforeTime: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
 		unselectableDaysAfterTime:  time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
 		unselectableDays:           make(map[time.Time]struct{}),
 		timezone:                   time.UTC,
+		numeralSystem:              NumeralsLatin,
+		loadLevels:                 append([]LoadLevel(nil), loadLevelsDefault...),
+		fullLoadThreshold:          fullLoadThresholdDefault,
 	}
 }
 
 // DayButtonTextWrapper add some extra beauty/info for buttons.
 func (bf *DayButtonFormer) DayButtonTextWrapper(incomeDay, incomeMonth, incomeYear int, currentTime time.Time) (string, bool) {
-	currentTime = currentTime.In(bf.timezone)
-	calendarDateTime := time.Date(incomeYear, time.Month(incomeMonth), incomeDay, currentTime.Hour(), currentTime.Minute(),
-		currentTime.Second(), currentTime.Nanosecond(), bf.timezone)
-	incomeDayS := strconv.Itoa(incomeDay)
-	resultButtonValue := new(strings.Builder)
-
-	resultButtonValue.Grow(len(incomeDayS))
-
-	isUnselectableDay := bf.isTimeUnselectable(FormDateTime(incomeDay, incomeMonth, incomeYear, bf.timezone))
-	if isUnselectableDay {
-		resultButtonValue.Grow(bf.buttons.prefixForNonSelectedDay.growLen)
-		resultButtonValue.Grow(bf.buttons.postfixForNonSelectedDay.growLen)
-	} else {
-		resultButtonValue.Grow(bf.buttons.prefixForPickDay.growLen)
-		resultButtonValue.Grow(bf.buttons.postfixForPickDay.growLen)
-	}
+	return bf.DayButtonTextWrapperWithParams(incomeDay, incomeMonth, incomeYear, currentTime, DayButtonParams{})
+}
 
-	isCurrentDay := isDatesEqual(calendarDateTime, currentTime.In(bf.timezone))
-	if isCurrentDay {
-		resultButtonValue.Grow(bf.buttons.prefixForCurrentDay.growLen)
-		resultButtonValue.Grow(bf.buttons.postfixForCurrentDay.growLen)
+// DayButtonTextWrapperContext same as DayButtonTextWrapperWithParams, but with the context for the providers
+// and the error of the load lookup. ctx overrides params.Context.
+func (bf *DayButtonFormer) DayButtonTextWrapperContext(
+	ctx context.Context,
+	incomeDay, incomeMonth, incomeYear int,
+	currentTime time.Time,
+	params DayButtonParams,
+) (string, bool, error) {
+	params.Context = ctx
+	text, isUnselectableDay, err := bf.dayButtonText(incomeDay, incomeMonth, incomeYear, currentTime, params)
+	if err == nil {
+		err = ctx.Err()
 	}
+	return text, isUnselectableDay, err
+}
 
-	// unselectable prefix.
-	if isUnselectableDay {
-		resultButtonValue.WriteString(bf.buttons.prefixForNonSelectedDay.value)
-	} else {
-		resultButtonValue.WriteString(bf.buttons.prefixForPickDay.value)
-	}
+// DayButtonTextWrapperWithParams same as DayButtonTextWrapper, but with per-render parameters.
+func (bf *DayButtonFormer) DayButtonTextWrapperWithParams(
+	incomeDay, incomeMonth, incomeYear int,
+	currentTime time.Time,
+	params DayButtonParams,
+) (string, bool) {
+	text, isUnselectableDay, _ := bf.dayButtonText(incomeDay, incomeMonth, incomeYear, currentTime, params)
+	return text, isUnselectableDay
+}
 
-	// cur day prefix.
-	if isCurrentDay {
-		resultButtonValue.WriteString(bf.buttons.prefixForCurrentDay.value)
+// The day with the unknown load (the lookup failed or is not made) is unselectable, the error is returned.
+func (bf *DayButtonFormer) dayButtonText(
+	incomeDay, incomeMonth, incomeYear int,
+	currentTime time.Time,
+	params DayButtonParams,
+) (string, bool, error) {
+	calendarDate := FormDateTime(incomeDay, incomeMonth, incomeYear, bf.timezone)
+	isUnselectableDay := bf.isTimeUnselectable(calendarDate) || bf.isBeforeMinimumLeadTime(calendarDate, currentTime)
+
+	ctx := params.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	load, hasLoad, err := bf.dayLoad(ctx, calendarDate)
+	if err != nil || (hasLoad && load >= bf.fullLoadThreshold) {
+		isUnselectableDay = true
 	}
 
-	// cur day.
-	resultButtonValue.WriteString(incomeDayS)
+	todayLocation := bf.timezone
+	if params.UserLocation != nil {
+		todayLocation = params.UserLocation
+	}
+	isCurrentDay := isDatesEqual(FormDateTime(incomeDay, incomeMonth, incomeYear, todayLocation), currentTime.In(todayLocation))
 
-	// cur day postfix.
-	if isCurrentDay {
-		resultButtonValue.WriteString(bf.buttons.postfixForCurrentDay.value)
+	day := DayContext{
+		Date:            calendarDate,
+		CurrentTime:     currentTime,
+		IsCurrentDay:    isCurrentDay,
+		IsUnselectable:  isUnselectableDay,
+		IsAdjacentMonth: params.IsAdjacentMonth,
+		IsPreselected:   !params.PreselectedDate.IsZero() && isDatesEqual(calendarDate, params.PreselectedDate),
+		NumeralSystem:   bf.numeralSystem,
+		Load:            load,
+		HasLoad:         hasLoad,
+		EventsMarker:    params.EventsMarker,
 	}
 
-	// unselectable postfix.
-	if isUnselectableDay {
-		resultButtonValue.WriteString(bf.buttons.postfixForNonSelectedDay.value)
-	} else {
-		resultButtonValue.WriteString(bf.buttons.postfixForPickDay.value)
+	text := bf.numeralSystem.Format(incomeDay)
+	for _, decorator := range bf.builtInDecorators() {
+		text = decorator.DecorateDay(day).apply(text)
+	}
+	for _, decorator := range bf.dayDecorators {
+		text = decorator.DecorateDay(day).apply(text)
 	}
 
-	return resultButtonValue.String(), isUnselectableDay
+	return text, isUnselectableDay, err
 }
 
 // Simple check date, don't compare time here.
@@ -151,24 +229,67 @@ func (bf *DayButtonFormer) isTimeUnselectable(calendarDateTime time.Time) bool {
 	return false
 }
 
-// GetUnselectableDays ...
+// Days before the date of currentTime + minimumLeadTime (in the former timezone) are unselectable.
+func (bf *DayButtonFormer) isBeforeMinimumLeadTime(calendarDate, currentTime time.Time) bool {
+	if bf.minimumLeadTime <= 0 {
+		return false
+	}
+
+	earliestTime := currentTime.Add(bf.minimumLeadTime).In(bf.timezone)
+	earliestDate := FormDateTime(earliestTime.Day(), int(earliestTime.Month()), earliestTime.Year(), bf.timezone)
+
+	return calendarDate.Before(earliestDate)
+}
+
+// GetUnselectableDays returns a copy of the unselectable days.
 func (bf *DayButtonFormer) GetUnselectableDays() map[time.Time]struct{} {
-	return bf.unselectableDays
+	return copyDays(bf.unselectableDays)
+}
+
+// Deep copy for the options: the maps and slices are not shared with the source.
+func (bf *DayButtonFormer) clone() *DayButtonFormer {
+	bfCopy := *bf
+	bfCopy.unselectableDays = copyDays(bf.unselectableDays)
+	bfCopy.dayDecorators = append([]DayDecorator(nil), bf.dayDecorators...)
+	bfCopy.loadLevels = append([]LoadLevel(nil), bf.loadLevels...)
+	return &bfCopy
+}
+
+func copyDays(days map[time.Time]struct{}) map[time.Time]struct{} {
+	if days == nil {
+		return nil
+	}
+
+	daysCopy := make(map[time.Time]struct{}, len(days))
+	for day := range days {
+		daysCopy[day] = struct{}{}
+	}
+	return daysCopy
 }
 
 // GetCurrentConfig ...
 func (bf *DayButtonFormer) GetCurrentConfig() FlatConfig {
 	return FlatConfig{
-		PrefixForCurrentDay:        bf.buttons.prefixForCurrentDay.value,
-		PostfixForCurrentDay:       bf.buttons.postfixForCurrentDay.value,
-		PrefixForNonSelectedDay:    bf.buttons.prefixForNonSelectedDay.value,
-		PostfixForNonSelectedDay:   bf.buttons.postfixForNonSelectedDay.value,
-		PrefixForPickDay:           bf.buttons.prefixForPickDay.value,
-		PostfixForPickDay:          bf.buttons.postfixForPickDay.value,
+		PrefixForCurrentDay:        bf.buttons.prefixForCurrentDay,
+		PostfixForCurrentDay:       bf.buttons.postfixForCurrentDay,
+		PrefixForNonSelectedDay:    bf.buttons.prefixForNonSelectedDay,
+		PostfixForNonSelectedDay:   bf.buttons.postfixForNonSelectedDay,
+		PrefixForPickDay:           bf.buttons.prefixForPickDay,
+		PostfixForPickDay:          bf.buttons.postfixForPickDay,
+		PrefixForAdjacentDay:       bf.buttons.prefixForAdjacentDay,
+		PostfixForAdjacentDay:      bf.buttons.postfixForAdjacentDay,
+		PrefixForPreselectedDay:    bf.buttons.prefixForPreselectedDay,
+		PostfixForPreselectedDay:   bf.buttons.postfixForPreselectedDay,
 		UnselectableDaysBeforeTime: bf.unselectableDaysBeforeTime,
 		UnselectableDaysAfterTime:  bf.unselectableDaysAfterTime,
-		UnselectableDays:           bf.unselectableDays,
+		UnselectableDays:           copyDays(bf.unselectableDays),
 		Timezone:                   *bf.timezone,
+		NumeralSystem:              bf.numeralSystem,
+		MinimumLeadTime:            bf.minimumLeadTime,
+		DayDecorators:              append([]DayDecorator(nil), bf.dayDecorators...),
+		LoadProvider:               bf.loadProvider,
+		LoadLevels:                 append([]LoadLevel(nil), bf.loadLevels...),
+		FullLoadThreshold:          bf.fullLoadThreshold,
 	}
 }
 
@@ -176,3 +297,8 @@ func (bf *DayButtonFormer) GetCurrentConfig() FlatConfig {
 func (bf *DayButtonFormer) GetTimezone() time.Location {
 	return *bf.timezone
 }
+
+// GetNumeralSystem digits of the days labels, the generator uses them for the years labels too.
+func (bf *DayButtonFormer) GetNumeralSystem() NumeralSystem {
+	return bf.numeralSystem
+}
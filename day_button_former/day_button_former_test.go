@@ -29,34 +29,34 @@ func TestNewButtonsFormer(t *testing.T) {
 		return
 	}
 
-	if bf.buttons.prefixForCurrentDay.value != poo && bf.buttons.prefixForCurrentDay.growLen != 4 {
-		t.Errorf("some go wrong when set prefixForCurrentDay, have %v, wan't %v with len %v",
-			bf.buttons.prefixForCurrentDay, poo, 4)
+	if bf.buttons.prefixForCurrentDay != poo {
+		t.Errorf("some go wrong when set prefixForCurrentDay, have %v, wan't %v",
+			bf.buttons.prefixForCurrentDay, poo)
 	}
 
-	if bf.buttons.postfixForCurrentDay.value != poo && bf.buttons.postfixForCurrentDay.growLen != 4 {
-		t.Errorf("some go wrong when set postfixForCurrentDay, have %v, wan't %v with len %v",
-			bf.buttons.postfixForCurrentDay, poo, 4)
+	if bf.buttons.postfixForCurrentDay != poo {
+		t.Errorf("some go wrong when set postfixForCurrentDay, have %v, wan't %v",
+			bf.buttons.postfixForCurrentDay, poo)
 	}
 
-	if bf.buttons.prefixForNonSelectedDay.value != poo && bf.buttons.prefixForNonSelectedDay.growLen != 4 {
-		t.Errorf("some go wrong when set prefixForNonSelectedDay, have %v, wan't %v with len %v",
-			bf.buttons.prefixForNonSelectedDay, poo, 4)
+	if bf.buttons.prefixForNonSelectedDay != poo {
+		t.Errorf("some go wrong when set prefixForNonSelectedDay, have %v, wan't %v",
+			bf.buttons.prefixForNonSelectedDay, poo)
 	}
 
-	if bf.buttons.postfixForNonSelectedDay.value != poo && bf.buttons.postfixForNonSelectedDay.growLen != 4 {
-		t.Errorf("some go wrong when set postfixForNonSelectedDay, have %v, wan't %v with len %v",
-			bf.buttons.postfixForNonSelectedDay, poo, 4)
+	if bf.buttons.postfixForNonSelectedDay != poo {
+		t.Errorf("some go wrong when set postfixForNonSelectedDay, have %v, wan't %v",
+			bf.buttons.postfixForNonSelectedDay, poo)
 	}
 
-	if bf.buttons.prefixForPickDay.value != poo && bf.buttons.prefixForPickDay.growLen != 4 {
-		t.Errorf("some go wrong when set prefixForPickDay, have %v, wan't %v with len %v",
-			bf.buttons.prefixForPickDay, poo, 4)
+	if bf.buttons.prefixForPickDay != poo {
+		t.Errorf("some go wrong when set prefixForPickDay, have %v, wan't %v",
+			bf.buttons.prefixForPickDay, poo)
 	}
 
-	if bf.buttons.postfixForPickDay.value != poo && bf.buttons.postfixForPickDay.growLen != 4 {
-		t.Errorf("some go wrong when set postfixForPickDay, have %v, wan't %v with len %v",
-			bf.buttons.postfixForPickDay, poo, 4)
+	if bf.buttons.postfixForPickDay != poo {
+		t.Errorf("some go wrong when set postfixForPickDay, have %v, wan't %v",
+			bf.buttons.postfixForPickDay, poo)
 	}
 
 	wantDaysBeforeDate := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
@@ -447,3 +447,37 @@ func TestGetTimezone(t *testing.T) {
 		t.Errorf("tzGot %v, tz want %v", tzGot, tzEuropeB)
 	}
 }
+
+func TestDayButtonTextWrapperForAdjacentDay(t *testing.T) {
+	t.Parallel()
+
+	bf := NewButtonsFormer(
+		ChangePrefixForAdjacentDay("("),
+		ChangePostfixForAdjacentDay(")"),
+		ChangePrefixForPickDay("+"),
+	)
+	currentTime := time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		day      int
+		params   DayButtonParams
+		expected string
+	}{
+		{name: "day of the month", day: 2, expected: "+2"},
+		{name: "adjacent day", day: 2, params: DayButtonParams{IsAdjacentMonth: true}, expected: "(+2)"},
+		{name: "adjacent current day", day: 1, params: DayButtonParams{IsAdjacentMonth: true}, expected: "(+1🗓)"},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, _ := DayButtonTextWithParams(bf, tt.day, 7, 2023, currentTime, tt.params)
+			if got != tt.expected {
+				t.Errorf("unexpected button text: got: %v, want: %v", got, tt.expected)
+			}
+		},
+		)
+	}
+}
@@ -0,0 +1,100 @@
+package day_button_former
+
+import "time"
+
+// DayContext is what a DayDecorator knows about the day.
+type DayContext struct {
+	// Date midnight of the day in the former timezone.
+	Date        time.Time
+	CurrentTime time.Time
+	// IsCurrentDay today at the user location (or at the former timezone).
+	IsCurrentDay    bool
+	IsUnselectable  bool
+	IsAdjacentMonth bool
+	// IsPreselected the user's previous choice.
+	IsPreselected bool
+	NumeralSystem NumeralSystem
+	// Load from 0 to 1 if there is a LoadProvider and it knows the day load.
+	Load    float64
+	HasLoad bool
+	// EventsMarker the events marker of the day, empty without events (see DayButtonParams).
+	EventsMarker string
+}
+
+// DayDecoration wraps the text built by the previous decorators: Prefix + (Text or previous text) + Postfix.
+type DayDecoration struct {
+	Prefix  string
+	Postfix string
+	// Text overrides the text built by the previous decorators, empty means no override.
+	Text string
+}
+
+// DayDecorator adds some text to the day button.
+// Decorators are applied in order, each one wraps the result of the previous one.
+type DayDecorator interface {
+	DecorateDay(day DayContext) DayDecoration
+}
+
+// DayDecoratorFunc adapter to use a function as DayDecorator.
+type DayDecoratorFunc func(day DayContext) DayDecoration
+
+// DecorateDay calls f(day).
+func (f DayDecoratorFunc) DecorateDay(day DayContext) DayDecoration {
+	return f(day)
+}
+
+// Built-in decorators from the prefix/postfix options, the innermost first:
+// current day, preselected day, selectability, load level, adjacent month, events marker.
+func (bf *DayButtonFormer) builtInDecorators() [6]DayDecorator {
+	return [...]DayDecorator{
+		DayDecoratorFunc(bf.currentDayDecoration),
+		DayDecoratorFunc(bf.preselectedDayDecoration),
+		DayDecoratorFunc(bf.selectabilityDecoration),
+		DayDecoratorFunc(bf.loadDecoration),
+		DayDecoratorFunc(bf.adjacentMonthDecoration),
+		DayDecoratorFunc(eventsDecoration),
+	}
+}
+
+func (bf *DayButtonFormer) currentDayDecoration(day DayContext) DayDecoration {
+	if !day.IsCurrentDay {
+		return DayDecoration{}
+	}
+	return DayDecoration{Prefix: bf.buttons.prefixForCurrentDay, Postfix: bf.buttons.postfixForCurrentDay}
+}
+
+func (bf *DayButtonFormer) preselectedDayDecoration(day DayContext) DayDecoration {
+	if !day.IsPreselected {
+		return DayDecoration{}
+	}
+	return DayDecoration{Prefix: bf.buttons.prefixForPreselectedDay, Postfix: bf.buttons.postfixForPreselectedDay}
+}
+
+func (bf *DayButtonFormer) selectabilityDecoration(day DayContext) DayDecoration {
+	if day.IsUnselectable {
+		return DayDecoration{Prefix: bf.buttons.prefixForNonSelectedDay, Postfix: bf.buttons.postfixForNonSelectedDay}
+	}
+	return DayDecoration{Prefix: bf.buttons.prefixForPickDay, Postfix: bf.buttons.postfixForPickDay}
+}
+
+func (bf *DayButtonFormer) adjacentMonthDecoration(day DayContext) DayDecoration {
+	if !day.IsAdjacentMonth {
+		return DayDecoration{}
+	}
+	return DayDecoration{Prefix: bf.buttons.prefixForAdjacentDay, Postfix: bf.buttons.postfixForAdjacentDay}
+}
+
+func eventsDecoration(day DayContext) DayDecoration {
+	return DayDecoration{Postfix: day.EventsMarker}
+}
+
+// Applies the decoration to the text built so far.
+func (d DayDecoration) apply(text string) string {
+	if d.Text != "" {
+		text = d.Text
+	}
+	if d.Prefix == "" && d.Postfix == "" {
+		return text
+	}
+	return d.Prefix + text + d.Postfix
+}
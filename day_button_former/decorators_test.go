@@ -0,0 +1,98 @@
+package day_button_former
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDayButtonTextWrapperWithDecorators(t *testing.T) {
+	t.Parallel()
+
+	currentTime := time.Date(2023, 6, 12, 10, 0, 0, 0, time.UTC)
+	bookings := map[int]int{12: 3, 20: 15}
+
+	bookingsCount := DayDecoratorFunc(func(day DayContext) DayDecoration {
+		if count, ok := bookings[day.Date.Day()]; ok {
+			return DayDecoration{Postfix: "·" + day.NumeralSystem.Format(count)}
+		}
+		return DayDecoration{}
+	})
+	popular := DayDecoratorFunc(func(day DayContext) DayDecoration {
+		if bookings[day.Date.Day()] > 10 {
+			return DayDecoration{Prefix: "🔥"}
+		}
+		return DayDecoration{}
+	})
+	hideUnselectable := DayDecoratorFunc(func(day DayContext) DayDecoration {
+		if day.IsUnselectable {
+			return DayDecoration{Text: "-"}
+		}
+		return DayDecoration{}
+	})
+
+	type args struct {
+		day     int
+		options []func(DaysButtonsText) DaysButtonsText
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		expected string
+	}{
+		{
+			name:     "without decorators",
+			args:     args{day: 12},
+			expected: "12🗓",
+		},
+		{
+			name: "decorators wrap built-in ones",
+			args: args{day: 12, options: []func(DaysButtonsText) DaysButtonsText{
+				ChangeDayDecorators(bookingsCount, popular),
+			}},
+			expected: "12🗓·3",
+		},
+		{
+			name: "decorators are applied in order",
+			args: args{day: 20, options: []func(DaysButtonsText) DaysButtonsText{
+				ChangeDayDecorators(bookingsCount), AddDayDecorators(popular),
+			}},
+			expected: "🔥20·15",
+		},
+		{
+			name: "decorators see numeral system",
+			args: args{day: 20, options: []func(DaysButtonsText) DaysButtonsText{
+				ChangeNumeralSystem(NumeralsArabicIndic), ChangeDayDecorators(bookingsCount),
+			}},
+			expected: "٢٠·١٥",
+		},
+		{
+			name: "text override",
+			args: args{day: 20, options: []func(DaysButtonsText) DaysButtonsText{
+				ChangeUnselectableDays(map[time.Time]struct{}{time.Date(2023, 6, 20, 0, 0, 0, 0, time.UTC): {}}),
+				ChangeDayDecorators(bookingsCount, hideUnselectable),
+			}},
+			expected: "-",
+		},
+		{
+			name: "change replaces added decorators",
+			args: args{day: 20, options: []func(DaysButtonsText) DaysButtonsText{
+				AddDayDecorators(bookingsCount), ChangeDayDecorators(popular),
+			}},
+			expected: "🔥20",
+		},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			bf := NewButtonsFormer(tt.args.options...)
+			got, _ := bf.DayButtonTextWrapper(tt.args.day, 6, 2023, currentTime)
+			if got != tt.expected {
+				t.Errorf("unexpected button text: got: %v, want: %v", got, tt.expected)
+			}
+		},
+		)
+	}
+}
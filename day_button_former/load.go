@@ -0,0 +1,103 @@
+package day_button_former
+
+import (
+	"context"
+	"time"
+)
+
+// LoadProvider returns how full the day is, from 0 (free) to 1 (full).
+// ok is false if the load is unknown, such day has no marker.
+type LoadProvider interface {
+	DayLoad(date time.Time) (load float64, ok bool)
+}
+
+// LoadProviderFunc adapter to use a function as LoadProvider.
+type LoadProviderFunc func(date time.Time) (float64, bool)
+
+// DayLoad calls f(date).
+func (f LoadProviderFunc) DayLoad(date time.Time) (float64, bool) {
+	return f(date)
+}
+
+// ContextLoadProvider LoadProvider that takes the render context, a database lookup for example.
+// The day may be full on the error, so it is unselectable. The lookup is not made once the context is done,
+// such days are unselectable as well.
+type ContextLoadProvider interface {
+	LoadProvider
+	DayLoadContext(ctx context.Context, date time.Time) (load float64, ok bool, err error)
+}
+
+// LoadProviderContextFunc adapter to use a function as ContextLoadProvider.
+type LoadProviderContextFunc func(ctx context.Context, date time.Time) (float64, bool, error)
+
+// DayLoad calls f(context.Background(), date).
+func (f LoadProviderContextFunc) DayLoad(date time.Time) (float64, bool) {
+	load, ok, err := f(context.Background(), date)
+	return load, ok && err == nil
+}
+
+// DayLoadContext calls f(ctx, date).
+func (f LoadProviderContextFunc) DayLoadContext(ctx context.Context, date time.Time) (float64, bool, error) {
+	return f(ctx, date)
+}
+
+// LoadLevel the marker for loads from From and up to the next level.
+type LoadLevel struct {
+	From   float64
+	Marker string
+}
+
+// Load of the day clamped to 0..1, ok is false without a provider or for the unknown load.
+// The error is returned if the lookup failed or is not made because ctx is done.
+func (bf *DayButtonFormer) dayLoad(ctx context.Context, date time.Time) (float64, bool, error) {
+	if bf.loadProvider == nil {
+		return 0, false, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, false, err
+	}
+
+	var (
+		load float64
+		ok   bool
+	)
+	if provider, isContextProvider := bf.loadProvider.(ContextLoadProvider); isContextProvider {
+		var err error
+		load, ok, err = provider.DayLoadContext(ctx, date)
+		if err != nil {
+			return 0, false, err
+		}
+	} else {
+		load, ok = bf.loadProvider.DayLoad(date)
+	}
+	if !ok {
+		return 0, false, nil
+	}
+	if load < 0 {
+		load = 0
+	}
+	if load > 1 {
+		load = 1
+	}
+
+	return load, true, nil
+}
+
+// The marker of the last level that starts not after the load, levels are sorted by From.
+func (bf *DayButtonFormer) loadMarker(load float64) string {
+	marker := ""
+	for _, level := range bf.loadLevels {
+		if load < level.From {
+			break
+		}
+		marker = level.Marker
+	}
+	return marker
+}
+
+func (bf *DayButtonFormer) loadDecoration(day DayContext) DayDecoration {
+	if !day.HasLoad {
+		return DayDecoration{}
+	}
+	return DayDecoration{Prefix: bf.loadMarker(day.Load)}
+}
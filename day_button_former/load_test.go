@@ -0,0 +1,144 @@
+package day_button_former
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDayButtonTextWrapperWithLoad(t *testing.T) {
+	t.Parallel()
+
+	currentTime := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	loads := map[int]float64{10: 0, 11: 0.5, 12: 0.8, 13: 1, 14: 1.5, 15: 0.9}
+	loadProvider := LoadProviderFunc(func(date time.Time) (float64, bool) {
+		load, ok := loads[date.Day()]
+		return load, ok
+	})
+
+	type wants struct {
+		text           string
+		isUnselectable bool
+	}
+
+	tests := []struct {
+		name    string
+		day     int
+		options []func(DaysButtonsText) DaysButtonsText
+		want    wants
+	}{
+		{name: "unknown load", day: 9, want: wants{text: "9"}},
+		{name: "free day", day: 10, want: wants{text: "🟢10"}},
+		{name: "half full day", day: 11, want: wants{text: "🟡11"}},
+		{name: "almost full day", day: 12, want: wants{text: "🟠12"}},
+		{name: "full day is unselectable", day: 13, want: wants{text: "🔴13❌", isUnselectable: true}},
+		{name: "load is clamped", day: 14, want: wants{text: "🔴14❌", isUnselectable: true}},
+		{
+			name:    "custom threshold",
+			day:     15,
+			options: []func(DaysButtonsText) DaysButtonsText{ChangeFullLoadThreshold(0.9)},
+			want:    wants{text: "🟠15❌", isUnselectable: true},
+		},
+		{
+			name: "custom levels are sorted",
+			day:  12,
+			options: []func(DaysButtonsText) DaysButtonsText{
+				ChangeLoadLevels(LoadLevel{From: 0.7, Marker: "▓"}, LoadLevel{From: 0, Marker: "░"}),
+			},
+			want: wants{text: "▓12"},
+		},
+		{
+			name:    "without provider",
+			day:     13,
+			options: []func(DaysButtonsText) DaysButtonsText{ChangeLoadProvider(nil)},
+			want:    wants{text: "13"},
+		},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			bf := NewButtonsFormer(append([]func(DaysButtonsText) DaysButtonsText{
+				ChangeLoadProvider(loadProvider),
+				ChangeUnselectableDaysBeforeDate(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)),
+			}, tt.options...)...)
+			text, isUnselectable := bf.DayButtonTextWrapper(tt.day, 6, 2023, currentTime)
+			if text != tt.want.text {
+				t.Errorf("unexpected button text: got: %v, want: %v", text, tt.want.text)
+			}
+			if isUnselectable != tt.want.isUnselectable {
+				t.Errorf("unexpected unselectable flag: got: %v, want: %v", isUnselectable, tt.want.isUnselectable)
+			}
+		},
+		)
+	}
+}
+
+type ctxKeyAtDayButtonFormer struct{}
+
+func TestDayButtonTextWrapperContext(t *testing.T) {
+	t.Parallel()
+
+	currentTime := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	errLookup := errors.New("lookup failed")
+	loadProvider := LoadProviderContextFunc(func(ctx context.Context, date time.Time) (float64, bool, error) {
+		switch ctx.Value(ctxKeyAtDayButtonFormer{}) {
+		case "full":
+			return 1, true, nil
+		case "failing":
+			return 1, true, errLookup
+		default:
+			return 0, true, nil
+		}
+	})
+	cancelledCtx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKeyAtDayButtonFormer{}, "full"))
+	cancel()
+
+	tests := []struct {
+		name               string
+		ctx                context.Context //nolint:containedctx // test case.
+		wantText           string
+		wantIsUnselectable bool
+		wantErr            error
+	}{
+		{
+			name:               "context value",
+			ctx:                context.WithValue(context.Background(), ctxKeyAtDayButtonFormer{}, "full"),
+			wantText:           "🔴12❌",
+			wantIsUnselectable: true,
+		},
+		{name: "background", ctx: context.Background(), wantText: "🟢12"},
+		{
+			// The day may be full, it is not offered.
+			name:               "provider error",
+			ctx:                context.WithValue(context.Background(), ctxKeyAtDayButtonFormer{}, "failing"),
+			wantText:           "12❌",
+			wantIsUnselectable: true,
+			wantErr:            errLookup,
+		},
+		{name: "cancelled", ctx: cancelledCtx, wantText: "12❌", wantIsUnselectable: true, wantErr: context.Canceled},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			bf, _ := NewButtonsFormer(ChangeLoadProvider(loadProvider)).(ContextDaysButtonsText)
+
+			text, isUnselectable, err := bf.DayButtonTextWrapperContext(tt.ctx, 12, 6, 2023, currentTime, DayButtonParams{})
+			if text != tt.wantText || isUnselectable != tt.wantIsUnselectable || !errors.Is(err, tt.wantErr) {
+				t.Errorf("DayButtonTextWrapperContext() = %v, %v, %v, want %v, %v, %v",
+					text, isUnselectable, err, tt.wantText, tt.wantIsUnselectable, tt.wantErr)
+			}
+
+			// The same context at the params.
+			text, isUnselectable = DayButtonTextWithParams(bf, 12, 6, 2023, currentTime, DayButtonParams{Context: tt.ctx})
+			if text != tt.wantText || isUnselectable != tt.wantIsUnselectable {
+				t.Errorf("DayButtonTextWrapperWithParams() = %v, %v, want %v, %v", text, isUnselectable, tt.wantText, tt.wantIsUnselectable)
+			}
+		},
+		)
+	}
+}
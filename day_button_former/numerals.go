@@ -0,0 +1,41 @@
+package day_button_former
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// NumeralSystem contains digits from zero to nine. Zero value works as NumeralsLatin.
+type NumeralSystem [10]rune
+
+// Built-in numeral systems.
+var (
+	NumeralsLatin       = NumeralSystem{'0', '1', '2', '3', '4', '5', '6', '7', '8', '9'} //nolint:gochecknoglobals
+	NumeralsArabicIndic = NumeralSystem{'٠', '١', '٢', '٣', '٤', '٥', '٦', '٧', '٨', '٩'} //nolint:gochecknoglobals
+	NumeralsPersian     = NumeralSystem{'۰', '۱', '۲', '۳', '۴', '۵', '۶', '۷', '۸', '۹'} //nolint:gochecknoglobals
+	NumeralsDevanagari  = NumeralSystem{'०', '१', '२', '३', '४', '५', '६', '७', '८', '९'} //nolint:gochecknoglobals
+	NumeralsBengali     = NumeralSystem{'০', '১', '২', '৩', '৪', '৫', '৬', '৭', '৮', '৯'} //nolint:gochecknoglobals
+	NumeralsThai        = NumeralSystem{'๐', '๑', '๒', '๓', '๔', '๕', '๖', '๗', '๘', '๙'} //nolint:gochecknoglobals
+)
+
+// Format writes the number with the digits of the numeral system. Used for labels only,
+// callback payloads always stay ASCII.
+func (ns NumeralSystem) Format(n int) string {
+	latin := strconv.Itoa(n)
+	if ns == (NumeralSystem{}) || ns == NumeralsLatin {
+		return latin
+	}
+
+	sb := new(strings.Builder)
+	sb.Grow(len(latin) * utf8.UTFMax)
+	for _, r := range latin {
+		if r >= '0' && r <= '9' {
+			sb.WriteRune(ns[r-'0'])
+			continue
+		}
+		sb.WriteRune(r) // minus sign.
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,51 @@
+package day_button_former
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNumeralSystemFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		numeralSystem NumeralSystem
+		number        int
+		want          string
+	}{
+		{name: "zero value", numeralSystem: NumeralSystem{}, number: 2023, want: "2023"},
+		{name: "latin", numeralSystem: NumeralsLatin, number: 31, want: "31"},
+		{name: "arabic-indic", numeralSystem: NumeralsArabicIndic, number: 2023, want: "٢٠٢٣"},
+		{name: "persian", numeralSystem: NumeralsPersian, number: 1402, want: "۱۴۰۲"},
+		{name: "devanagari", numeralSystem: NumeralsDevanagari, number: 15, want: "१५"},
+		{name: "bengali", numeralSystem: NumeralsBengali, number: 7, want: "৭"},
+		{name: "thai", numeralSystem: NumeralsThai, number: 2566, want: "๒๕๖๖"},
+		{name: "negative", numeralSystem: NumeralsThai, number: -10, want: "-๑๐"},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.numeralSystem.Format(tt.number); got != tt.want {
+				t.Errorf("at Format(%v) got: %v, want: %v", tt.number, got, tt.want)
+			}
+		},
+		)
+	}
+}
+
+func TestDayButtonTextWrapperWithNumeralSystem(t *testing.T) {
+	t.Parallel()
+
+	bf := NewButtonsFormer(
+		ChangeNumeralSystem(NumeralsArabicIndic),
+		ChangePostfixForCurrentDay(""),
+	)
+
+	text, _ := bf.DayButtonTextWrapper(12, 6, 2023, time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC))
+	if text != "١٢" {
+		t.Errorf("unexpected day text: got: %v, want: %v", text, "١٢")
+	}
+}
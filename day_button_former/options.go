@@ -1,10 +1,14 @@
 package day_button_former
 
-import "time"
+import (
+	"sort"
+	"time"
+)
 
-// ApplyNewOptions ...
+// ApplyNewOptions applies the options to a copy, the former itself is never changed,
+// so it can be shared by concurrent renders.
 func (bf *DayButtonFormer) ApplyNewOptions(options ...func(DaysButtonsText) DaysButtonsText) DaysButtonsText {
-	var dbf DaysButtonsText = bf
+	var dbf DaysButtonsText = bf.clone()
 	for _, option := range options {
 		dbf = option(dbf)
 	}
@@ -14,128 +18,202 @@ func (bf *DayButtonFormer) ApplyNewOptions(options ...func(DaysButtonsText) Days
 // ChangePrefixForCurrentDay ...
 func ChangePrefixForCurrentDay(v string) func(DaysButtonsText) DaysButtonsText {
 	return func(bf DaysButtonsText) DaysButtonsText {
-		if dbf, ok := bf.(*DayButtonFormer); ok {
-			dbf.buttons.prefixForCurrentDay = extraButtonInfo{
-				value:   v,
-				growLen: len(v),
-			}
-			return dbf
-		}
-		return bf
+		return configure(bf, func(dbf *DayButtonFormer) {
+			dbf.buttons.prefixForCurrentDay = v
+		})
 	}
 }
 
 // ChangePostfixForCurrentDay ...
 func ChangePostfixForCurrentDay(v string) func(DaysButtonsText) DaysButtonsText {
 	return func(bf DaysButtonsText) DaysButtonsText {
-		if dbf, ok := bf.(*DayButtonFormer); ok {
-			dbf.buttons.postfixForCurrentDay = extraButtonInfo{
-				value:   v,
-				growLen: len(v),
-			}
-			return dbf
-		}
-		return bf
+		return configure(bf, func(dbf *DayButtonFormer) {
+			dbf.buttons.postfixForCurrentDay = v
+		})
 	}
 }
 
 // ChangePrefixForNonSelectedDay ...
 func ChangePrefixForNonSelectedDay(v string) func(DaysButtonsText) DaysButtonsText {
 	return func(bf DaysButtonsText) DaysButtonsText {
-		if dbf, ok := bf.(*DayButtonFormer); ok {
-			dbf.buttons.prefixForNonSelectedDay = extraButtonInfo{
-				value:   v,
-				growLen: len(v),
-			}
-			return dbf
-		}
-		return bf
+		return configure(bf, func(dbf *DayButtonFormer) {
+			dbf.buttons.prefixForNonSelectedDay = v
+		})
 	}
 }
 
 // ChangePostfixForNonSelectedDay ...
 func ChangePostfixForNonSelectedDay(v string) func(DaysButtonsText) DaysButtonsText {
 	return func(bf DaysButtonsText) DaysButtonsText {
-		if dbf, ok := bf.(*DayButtonFormer); ok {
-			dbf.buttons.postfixForNonSelectedDay = extraButtonInfo{
-				value:   v,
-				growLen: len(v),
-			}
-			return dbf
-		}
-		return bf
+		return configure(bf, func(dbf *DayButtonFormer) {
+			dbf.buttons.postfixForNonSelectedDay = v
+		})
 	}
 }
 
 // ChangePrefixForPickDay ...
 func ChangePrefixForPickDay(v string) func(DaysButtonsText) DaysButtonsText {
 	return func(bf DaysButtonsText) DaysButtonsText {
-		if dbf, ok := bf.(*DayButtonFormer); ok {
-			dbf.buttons.prefixForPickDay = extraButtonInfo{
-				value:   v,
-				growLen: len(v),
-			}
-			return dbf
-		}
-		return bf
+		return configure(bf, func(dbf *DayButtonFormer) {
+			dbf.buttons.prefixForPickDay = v
+		})
 	}
 }
 
 // ChangePostfixForPickDay ...
 func ChangePostfixForPickDay(v string) func(DaysButtonsText) DaysButtonsText {
 	return func(bf DaysButtonsText) DaysButtonsText {
-		if dbf, ok := bf.(*DayButtonFormer); ok {
-			dbf.buttons.postfixForPickDay = extraButtonInfo{
-				value:   v,
-				growLen: len(v),
-			}
-			return dbf
-		}
-		return bf
+		return configure(bf, func(dbf *DayButtonFormer) {
+			dbf.buttons.postfixForPickDay = v
+		})
+	}
+}
+
+// ChangePrefixForAdjacentDay prefix for days of the previous and the next month.
+func ChangePrefixForAdjacentDay(v string) func(DaysButtonsText) DaysButtonsText {
+	return func(bf DaysButtonsText) DaysButtonsText {
+		return configure(bf, func(dbf *DayButtonFormer) {
+			dbf.buttons.prefixForAdjacentDay = v
+		})
+	}
+}
+
+// ChangePostfixForAdjacentDay postfix for days of the previous and the next month.
+func ChangePostfixForAdjacentDay(v string) func(DaysButtonsText) DaysButtonsText {
+	return func(bf DaysButtonsText) DaysButtonsText {
+		return configure(bf, func(dbf *DayButtonFormer) {
+			dbf.buttons.postfixForAdjacentDay = v
+		})
+	}
+}
+
+// ChangePrefixForPreselectedDay prefix for the user's previous choice, see generator.WithPreselectedDate.
+func ChangePrefixForPreselectedDay(v string) func(DaysButtonsText) DaysButtonsText {
+	return func(bf DaysButtonsText) DaysButtonsText {
+		return configure(bf, func(dbf *DayButtonFormer) {
+			dbf.buttons.prefixForPreselectedDay = v
+		})
+	}
+}
+
+// ChangePostfixForPreselectedDay postfix for the user's previous choice, see generator.WithPreselectedDate.
+func ChangePostfixForPreselectedDay(v string) func(DaysButtonsText) DaysButtonsText {
+	return func(bf DaysButtonsText) DaysButtonsText {
+		return configure(bf, func(dbf *DayButtonFormer) {
+			dbf.buttons.postfixForPreselectedDay = v
+		})
+	}
+}
+
+// ChangeDayDecorators replaces the caller's decorators. They are applied in order after the built-in ones
+// (current day, selectability and adjacent month prefix/postfix, the events marker), each one wraps the result
+// of the previous one.
+func ChangeDayDecorators(decorators ...DayDecorator) func(DaysButtonsText) DaysButtonsText {
+	return func(bf DaysButtonsText) DaysButtonsText {
+		return configure(bf, func(dbf *DayButtonFormer) {
+			dbf.dayDecorators = append([]DayDecorator(nil), decorators...)
+		})
+	}
+}
+
+// AddDayDecorators appends decorators after the already added ones.
+func AddDayDecorators(decorators ...DayDecorator) func(DaysButtonsText) DaysButtonsText {
+	return func(bf DaysButtonsText) DaysButtonsText {
+		return configure(bf, func(dbf *DayButtonFormer) {
+			dbf.dayDecorators = append(append([]DayDecorator(nil), dbf.dayDecorators...), decorators...)
+		})
+	}
+}
+
+// ChangeLoadProvider adds the load level marker to the days, full days are unselectable.
+// Nil provider disables the markers.
+func ChangeLoadProvider(loadProvider LoadProvider) func(DaysButtonsText) DaysButtonsText {
+	return func(bf DaysButtonsText) DaysButtonsText {
+		return configure(bf, func(dbf *DayButtonFormer) {
+			dbf.loadProvider = loadProvider
+		})
+	}
+}
+
+// ChangeLoadLevels markers of the load levels, sorted by From.
+func ChangeLoadLevels(levels ...LoadLevel) func(DaysButtonsText) DaysButtonsText {
+	return func(bf DaysButtonsText) DaysButtonsText {
+		return configure(bf, func(dbf *DayButtonFormer) {
+			dbf.loadLevels = append([]LoadLevel(nil), levels...)
+			sort.SliceStable(dbf.loadLevels, func(i, j int) bool {
+				return dbf.loadLevels[i].From < dbf.loadLevels[j].From
+			})
+		})
+	}
+}
+
+// ChangeFullLoadThreshold days with the load not less than threshold are unselectable.
+// Threshold above 1 keeps all days selectable.
+func ChangeFullLoadThreshold(threshold float64) func(DaysButtonsText) DaysButtonsText {
+	return func(bf DaysButtonsText) DaysButtonsText {
+		return configure(bf, func(dbf *DayButtonFormer) {
+			dbf.fullLoadThreshold = threshold
+		})
 	}
 }
 
 // ChangeUnselectableDaysBeforeDate ...
 func ChangeUnselectableDaysBeforeDate(t time.Time) func(DaysButtonsText) DaysButtonsText {
 	return func(bf DaysButtonsText) DaysButtonsText {
-		if dbf, ok := bf.(*DayButtonFormer); ok {
+		return configure(bf, func(dbf *DayButtonFormer) {
 			dbf.unselectableDaysBeforeTime = t.In(dbf.timezone)
-			return dbf
-		}
-		return bf
+		})
 	}
 }
 
 // ChangeUnselectableDaysAfterDate ...
 func ChangeUnselectableDaysAfterDate(t time.Time) func(DaysButtonsText) DaysButtonsText {
 	return func(bf DaysButtonsText) DaysButtonsText {
-		if dbf, ok := bf.(*DayButtonFormer); ok {
+		return configure(bf, func(dbf *DayButtonFormer) {
 			dbf.unselectableDaysAfterTime = t.In(dbf.timezone)
-			return dbf
-		}
-		return bf
+		})
 	}
 }
 
 // ChangeUnselectableDays ...
 func ChangeUnselectableDays(unselectableDays map[time.Time]struct{}) func(DaysButtonsText) DaysButtonsText {
 	return func(bf DaysButtonsText) DaysButtonsText {
-		if dbf, ok := bf.(*DayButtonFormer); ok {
+		return configure(bf, func(dbf *DayButtonFormer) {
 			newUnselectableDays := make(map[time.Time]struct{}, len(unselectableDays))
 			for k := range unselectableDays {
 				newUnselectableDays[k.In(dbf.timezone)] = struct{}{}
 			}
 			dbf.unselectableDays = newUnselectableDays
-			return dbf
-		}
-		return bf
+		})
+	}
+}
+
+// ChangeMinimumLeadTime days before the date of current time + lead time are unselectable.
+// For example 24 hours means "not earlier than tomorrow". Zero or negative value disables the rule (default).
+func ChangeMinimumLeadTime(leadTime time.Duration) func(DaysButtonsText) DaysButtonsText {
+	return func(bf DaysButtonsText) DaysButtonsText {
+		return configure(bf, func(dbf *DayButtonFormer) {
+			if leadTime < 0 {
+				leadTime = 0
+			}
+			dbf.minimumLeadTime = leadTime
+		})
+	}
+}
+
+// ChangeNumeralSystem changes digits of the days labels.
+func ChangeNumeralSystem(numeralSystem NumeralSystem) func(DaysButtonsText) DaysButtonsText {
+	return func(bf DaysButtonsText) DaysButtonsText {
+		return configure(bf, func(dbf *DayButtonFormer) {
+			dbf.numeralSystem = numeralSystem
+		})
 	}
 }
 
 // ChangeTimezone also changes timezones for all current settings.
 func ChangeTimezone(t *time.Location) func(DaysButtonsText) DaysButtonsText {
 	return func(bf DaysButtonsText) DaysButtonsText {
-		if dbf, ok := bf.(*DayButtonFormer); ok {
+		return configure(bf, func(dbf *DayButtonFormer) {
 			if t == nil {
 				t = time.UTC
 			}
@@ -148,9 +226,6 @@ func ChangeTimezone(t *time.Location) func(DaysButtonsText) DaysButtonsText {
 				newUnselectableDays[ud.In(t)] = struct{}{}
 			}
 			dbf.unselectableDays = newUnselectableDays
-
-			return dbf
-		}
-		return bf
+		})
 	}
 }
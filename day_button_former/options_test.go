@@ -250,28 +250,22 @@ func isDayButtonDataFieldsExpected(
 	unselectableDays map[time.Time]struct{},
 	location *time.Location,
 ) bool {
-	if bf.buttons.prefixForCurrentDay.value != prefixForCurrentDay ||
-		bf.buttons.prefixForCurrentDay.growLen != len(prefixForCurrentDay) {
+	if bf.buttons.prefixForCurrentDay != prefixForCurrentDay {
 		return false
 	}
-	if bf.buttons.postfixForCurrentDay.value != postfixForCurrentDay ||
-		bf.buttons.postfixForCurrentDay.growLen != len(postfixForCurrentDay) {
+	if bf.buttons.postfixForCurrentDay != postfixForCurrentDay {
 		return false
 	}
-	if bf.buttons.prefixForNonSelectedDay.value != prefixForNonSelectedDay ||
-		bf.buttons.prefixForNonSelectedDay.growLen != len(prefixForNonSelectedDay) {
+	if bf.buttons.prefixForNonSelectedDay != prefixForNonSelectedDay {
 		return false
 	}
-	if bf.buttons.postfixForNonSelectedDay.value != postfixForNonSelectedDay ||
-		bf.buttons.postfixForNonSelectedDay.growLen != len(postfixForNonSelectedDay) {
+	if bf.buttons.postfixForNonSelectedDay != postfixForNonSelectedDay {
 		return false
 	}
-	if bf.buttons.prefixForPickDay.value != prefixForPickDay ||
-		bf.buttons.prefixForPickDay.growLen != len(prefixForPickDay) {
+	if bf.buttons.prefixForPickDay != prefixForPickDay {
 		return false
 	}
-	if bf.buttons.postfixForPickDay.value != postfixForPickDay ||
-		bf.buttons.postfixForPickDay.growLen != len(postfixForPickDay) {
+	if bf.buttons.postfixForPickDay != postfixForPickDay {
 		return false
 	}
 
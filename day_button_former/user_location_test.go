@@ -0,0 +1,255 @@
+package day_button_former
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDayButtonTextWrapperWithUserLocation(t *testing.T) {
+	t.Parallel()
+
+	tzAmericaNY, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Errorf("at time.LoadLocation for America/New_York error: %v", err)
+		return
+	}
+	tzEuropeB, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Errorf("at time.LoadLocation for Europe/Berlin error: %v", err)
+		return
+	}
+
+	bf := NewButtonsFormer(
+		ChangePostfixForCurrentDay("*"),
+		ChangePostfixForNonSelectedDay(""),
+	)
+
+	type args struct {
+		incomeDay    int
+		incomeMonth  int
+		incomeYear   int
+		currentTime  time.Time
+		userLocation *time.Location
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		expected string
+	}{
+		{
+			name: "without user location today is at the former timezone",
+			args: args{
+				incomeDay: 12, incomeMonth: 3, incomeYear: 2023,
+				currentTime: time.Date(2023, 3, 12, 3, 30, 0, 0, time.UTC),
+			},
+			expected: "12*",
+		},
+		{
+			name: "user is still at the previous day",
+			args: args{
+				incomeDay: 11, incomeMonth: 3, incomeYear: 2023,
+				currentTime:  time.Date(2023, 3, 12, 3, 30, 0, 0, time.UTC),
+				userLocation: tzAmericaNY,
+			},
+			expected: "11*",
+		},
+		{
+			name: "former today is not the user today",
+			args: args{
+				incomeDay: 12, incomeMonth: 3, incomeYear: 2023,
+				currentTime:  time.Date(2023, 3, 12, 3, 30, 0, 0, time.UTC),
+				userLocation: tzAmericaNY,
+			},
+			expected: "12",
+		},
+		{
+			name: "dst starts: 01:59 EST is the last minute before the jump",
+			args: args{
+				incomeDay: 12, incomeMonth: 3, incomeYear: 2023,
+				currentTime:  time.Date(2023, 3, 12, 6, 59, 0, 0, time.UTC),
+				userLocation: tzAmericaNY,
+			},
+			expected: "12*",
+		},
+		{
+			name: "dst ends: 23:30 EDT is still the same day",
+			args: args{
+				incomeDay: 4, incomeMonth: 11, incomeYear: 2023,
+				currentTime:  time.Date(2023, 11, 5, 3, 30, 0, 0, time.UTC),
+				userLocation: tzAmericaNY,
+			},
+			expected: "4*",
+		},
+		{
+			name: "dst ends: repeated hour belongs to the next day",
+			args: args{
+				incomeDay: 5, incomeMonth: 11, incomeYear: 2023,
+				currentTime:  time.Date(2023, 11, 5, 5, 30, 0, 0, time.UTC),
+				userLocation: tzAmericaNY,
+			},
+			expected: "5*",
+		},
+		{
+			name: "dst starts at berlin: 00:30 UTC is 01:30 CET",
+			args: args{
+				incomeDay: 26, incomeMonth: 3, incomeYear: 2023,
+				currentTime:  time.Date(2023, 3, 25, 23, 30, 0, 0, time.UTC),
+				userLocation: tzEuropeB,
+			},
+			expected: "26*",
+		},
+		{
+			name: "dst ends at berlin: 22:30 UTC is 23:30 CET",
+			args: args{
+				incomeDay: 29, incomeMonth: 10, incomeYear: 2023,
+				currentTime:  time.Date(2023, 10, 29, 22, 30, 0, 0, time.UTC),
+				userLocation: tzEuropeB,
+			},
+			expected: "29*",
+		},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result, _ := DayButtonTextWithParams(bf, tt.args.incomeDay, tt.args.incomeMonth, tt.args.incomeYear,
+				tt.args.currentTime, DayButtonParams{UserLocation: tt.args.userLocation})
+			if tt.expected != result {
+				t.Errorf("expected button text %v != what we got %v", tt.expected, result)
+			}
+		},
+		)
+	}
+}
+
+func TestMinimumLeadTime(t *testing.T) {
+	t.Parallel()
+
+	tzEuropeB, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Errorf("at time.LoadLocation for Europe/Berlin error: %v", err)
+		return
+	}
+
+	type args struct {
+		leadTime     time.Duration
+		timezone     *time.Location
+		incomeDay    int
+		incomeMonth  int
+		incomeYear   int
+		currentTime  time.Time
+		userLocation *time.Location
+	}
+
+	tests := []struct {
+		name                   string
+		args                   args
+		expectedIsUnselectable bool
+	}{
+		{
+			name: "negative lead time disables the rule",
+			args: args{
+				leadTime: -1, timezone: time.UTC,
+				incomeDay: 1, incomeMonth: 6, incomeYear: 2023,
+				currentTime: time.Date(2023, 6, 10, 12, 0, 0, 0, time.UTC),
+			},
+			expectedIsUnselectable: false,
+		},
+		{
+			name: "zero lead time disables the rule",
+			args: args{
+				leadTime: 0, timezone: time.UTC,
+				incomeDay: 9, incomeMonth: 6, incomeYear: 2023,
+				currentTime: time.Date(2023, 6, 10, 12, 0, 0, 0, time.UTC),
+			},
+			expectedIsUnselectable: false,
+		},
+		{
+			name: "minute lead time, yesterday",
+			args: args{
+				leadTime: time.Minute, timezone: time.UTC,
+				incomeDay: 9, incomeMonth: 6, incomeYear: 2023,
+				currentTime: time.Date(2023, 6, 10, 12, 0, 0, 0, time.UTC),
+			},
+			expectedIsUnselectable: true,
+		},
+		{
+			name: "minute lead time, today",
+			args: args{
+				leadTime: time.Minute, timezone: time.UTC,
+				incomeDay: 10, incomeMonth: 6, incomeYear: 2023,
+				currentTime: time.Date(2023, 6, 10, 23, 58, 0, 0, time.UTC),
+			},
+			expectedIsUnselectable: false,
+		},
+		{
+			name: "not earlier than tomorrow, today",
+			args: args{
+				leadTime: 24 * time.Hour, timezone: time.UTC,
+				incomeDay: 10, incomeMonth: 6, incomeYear: 2023,
+				currentTime: time.Date(2023, 6, 10, 0, 0, 0, 0, time.UTC),
+			},
+			expectedIsUnselectable: true,
+		},
+		{
+			name: "not earlier than tomorrow, tomorrow",
+			args: args{
+				leadTime: 24 * time.Hour, timezone: time.UTC,
+				incomeDay: 11, incomeMonth: 6, incomeYear: 2023,
+				currentTime: time.Date(2023, 6, 10, 23, 0, 0, 0, time.UTC),
+			},
+			expectedIsUnselectable: false,
+		},
+		{
+			name: "rule uses the former timezone, not the user location",
+			args: args{
+				leadTime: 24 * time.Hour, timezone: tzEuropeB,
+				incomeDay: 11, incomeMonth: 6, incomeYear: 2023,
+				// 10 June 22:30 at UTC, but already 11 June 00:30 at Berlin.
+				currentTime:  time.Date(2023, 6, 10, 22, 30, 0, 0, time.UTC),
+				userLocation: time.UTC,
+			},
+			expectedIsUnselectable: true,
+		},
+		{
+			name: "dst starts: 24 hours lead time from 23:30 CET skips the short day",
+			args: args{
+				leadTime: 24 * time.Hour, timezone: tzEuropeB,
+				incomeDay: 26, incomeMonth: 3, incomeYear: 2023,
+				// 25 March 23:30 CET + 24h = 27 March 00:30 CEST, 26 March is 23 hours long.
+				currentTime: time.Date(2023, 3, 25, 22, 30, 0, 0, time.UTC),
+			},
+			expectedIsUnselectable: true,
+		},
+		{
+			name: "dst ends: 24 hours lead time from 00:30 CEST stays at the long day",
+			args: args{
+				leadTime: 24 * time.Hour, timezone: tzEuropeB,
+				incomeDay: 30, incomeMonth: 10, incomeYear: 2023,
+				// 29 October 00:30 CEST + 24h = 29 October 23:30 CET, 29 October is 25 hours long.
+				currentTime: time.Date(2023, 10, 28, 22, 30, 0, 0, time.UTC),
+			},
+			expectedIsUnselectable: false,
+		},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			bf := NewButtonsFormer(
+				ChangeTimezone(tt.args.timezone),
+				ChangeUnselectableDaysBeforeDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)),
+				ChangeMinimumLeadTime(tt.args.leadTime),
+			)
+			_, isUnselectable := DayButtonTextWithParams(bf, tt.args.incomeDay, tt.args.incomeMonth, tt.args.incomeYear,
+				tt.args.currentTime, DayButtonParams{UserLocation: tt.args.userLocation})
+			if tt.expectedIsUnselectable != isUnselectable {
+				t.Errorf("expected is unselectable %v != what we got %v", tt.expectedIsUnselectable, isUnselectable)
+			}
+		},
+		)
+	}
+}
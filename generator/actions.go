@@ -0,0 +1,52 @@
+package generator
+
+// ActionName returns the readable name of the calendar payload action ("prm" is "prev_month", for example),
+// false for the actions GenerateCalendarKeyboard does not know. The empty action opens the default calendar.
+func ActionName(action string) (string, bool) {
+	switch action {
+	case goToDefaultKeyboard:
+		return "open", true
+	case prevMonthAction:
+		return "prev_month", true
+	case nextMonthAction:
+		return "next_month", true
+	case prevYearAction:
+		return "prev_year", true
+	case nextYearAction:
+		return "next_year", true
+	case selectMonthAction:
+		return "select_month", true
+	case selectYearAction:
+		return "select_year", true
+	case showSelectedAction:
+		return "show_selected", true
+	case silentDoNothingAction:
+		return "do_nothing", true
+	case selectDayAction:
+		return "select_day", true
+	case unselectableDaySelected:
+		return "unselectable_day", true
+	case eventsDayAction:
+		return "events_day", true
+	case todayAction:
+		return "today", true
+	case clearAction:
+		return "clear", true
+	case cancelAction:
+		return "cancel", true
+	case confirmAction:
+		return "confirm", true
+	default:
+		return "", false
+	}
+}
+
+// The readable action of the decoded payload for the response, empty if the payload is not decoded
+// (the decoders return the empty action, the one of the empty payload, for the wrong payloads).
+func payloadActionName(callbackPayload, action string) string {
+	if action == goToDefaultKeyboard && callbackPayload != "" {
+		return ""
+	}
+	name, _ := ActionName(action)
+	return name
+}
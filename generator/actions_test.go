@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestActionName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		action   string
+		wantName string
+		wantOk   bool
+	}{
+		{action: "", wantName: "open", wantOk: true},
+		{action: prevMonthAction, wantName: "prev_month", wantOk: true},
+		{action: selectDayAction, wantName: "select_day", wantOk: true},
+		{action: unselectableDaySelected, wantName: "unselectable_day", wantOk: true},
+		{action: confirmAction, wantName: "confirm", wantOk: true},
+		{action: "xyz"},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.action, func(t *testing.T) {
+			t.Parallel()
+			gotName, gotOk := ActionName(tt.action)
+			if gotName != tt.wantName || gotOk != tt.wantOk {
+				t.Errorf("ActionName() = %v, %v, want %v, %v", gotName, gotOk, tt.wantName, tt.wantOk)
+			}
+		},
+		)
+	}
+}
+
+func TestGenerateCalendarKeyboardAction(t *testing.T) {
+	t.Parallel()
+
+	kf := NewKeyboardFormer()
+	currentTime := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		callbackPayload string
+		wantAction      string
+	}{
+		{callbackPayload: "", wantAction: "open"},
+		{callbackPayload: "calendar/nem_00.06.2023", wantAction: "next_month"},
+		{callbackPayload: "calendar/sed_12.06.2023", wantAction: "select_day"},
+		{callbackPayload: "calendar/xyz_01.06.2023"},
+		{callbackPayload: "hello"},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.callbackPayload, func(t *testing.T) {
+			t.Parallel()
+			if got := kf.GenerateCalendarKeyboard(tt.callbackPayload, currentTime).Action; got != tt.wantAction {
+				t.Errorf("unexpected action: got: %v, want: %v", got, tt.wantAction)
+			}
+		},
+		)
+	}
+}
@@ -0,0 +1,14 @@
+package generator
+
+// AdjacentDaysMode is how the first and the last week cells outside of the month are rendered.
+type AdjacentDaysMode int
+
+// Adjacent days modes.
+const (
+	// AdjacentDaysHidden blank buttons, the default.
+	AdjacentDaysHidden AdjacentDaysMode = iota
+	// AdjacentDaysSelect days of the previous and the next month, a tap selects the date.
+	AdjacentDaysSelect
+	// AdjacentDaysNavigate days of the previous and the next month, a tap opens that month.
+	AdjacentDaysNavigate
+)
@@ -0,0 +1,138 @@
+package generator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thevan4/telegram-calendar/day_button_former"
+	"github.com/thevan4/telegram-calendar/models"
+)
+
+func TestGenerateCurrentMonthWithAdjacentDays(t *testing.T) {
+	t.Parallel()
+
+	// June 2023 starts on Thursday and ends on Friday.
+	currentTime := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	type wants struct {
+		firstWeek []models.InlineKeyboardButton
+		lastWeek  []models.InlineKeyboardButton
+	}
+
+	tests := []struct {
+		name    string
+		options []func(KeyboardGenerator) KeyboardGenerator
+		want    wants
+	}{
+		{
+			name: "hidden by default",
+			want: wants{
+				firstWeek: []models.InlineKeyboardButton{
+					{Text: " ", CallbackData: "calendar/sdn_00.06.2023"},
+					{Text: " ", CallbackData: "calendar/sdn_00.06.2023"},
+					{Text: " ", CallbackData: "calendar/sdn_00.06.2023"},
+					{Text: "1", CallbackData: "calendar/sed_01.06.2023"},
+					{Text: "2", CallbackData: "calendar/sed_02.06.2023"},
+					{Text: "3", CallbackData: "calendar/sed_03.06.2023"},
+					{Text: "4", CallbackData: "calendar/sed_04.06.2023"},
+				},
+				lastWeek: []models.InlineKeyboardButton{
+					{Text: "26", CallbackData: "calendar/sed_26.06.2023"},
+					{Text: "27", CallbackData: "calendar/sed_27.06.2023"},
+					{Text: "28", CallbackData: "calendar/sed_28.06.2023"},
+					{Text: "29", CallbackData: "calendar/sed_29.06.2023"},
+					{Text: "30", CallbackData: "calendar/sed_30.06.2023"},
+					{Text: " ", CallbackData: "calendar/sdn_00.06.2023"},
+					{Text: " ", CallbackData: "calendar/sdn_00.06.2023"},
+				},
+			},
+		},
+		{
+			name:    "select mode",
+			options: []func(KeyboardGenerator) KeyboardGenerator{ChangeAdjacentDaysMode(AdjacentDaysSelect)},
+			want: wants{
+				firstWeek: []models.InlineKeyboardButton{
+					{Text: "·29", CallbackData: "calendar/sed_29.05.2023"},
+					{Text: "·30", CallbackData: "calendar/sed_30.05.2023"},
+					{Text: "·31", CallbackData: "calendar/sed_31.05.2023"},
+					{Text: "1", CallbackData: "calendar/sed_01.06.2023"},
+					{Text: "2", CallbackData: "calendar/sed_02.06.2023"},
+					{Text: "3", CallbackData: "calendar/sed_03.06.2023"},
+					{Text: "4", CallbackData: "calendar/sed_04.06.2023"},
+				},
+				lastWeek: []models.InlineKeyboardButton{
+					{Text: "26", CallbackData: "calendar/sed_26.06.2023"},
+					{Text: "27", CallbackData: "calendar/sed_27.06.2023"},
+					{Text: "28", CallbackData: "calendar/sed_28.06.2023"},
+					{Text: "29", CallbackData: "calendar/sed_29.06.2023"},
+					{Text: "30", CallbackData: "calendar/sed_30.06.2023"},
+					{Text: "·1", CallbackData: "calendar/sed_01.07.2023"},
+					{Text: "·2", CallbackData: "calendar/sed_02.07.2023"},
+				},
+			},
+		},
+		{
+			name: "navigate mode with own styling and unselectable days",
+			options: []func(KeyboardGenerator) KeyboardGenerator{
+				ChangeAdjacentDaysMode(AdjacentDaysNavigate),
+				ApplyNewOptionsForButtonsTextWrapper(
+					day_button_former.ChangePrefixForAdjacentDay("("),
+					day_button_former.ChangePostfixForAdjacentDay(")"),
+					day_button_former.ChangeUnselectableDaysAfterDate(time.Date(2023, 6, 30, 0, 0, 0, 0, time.UTC)),
+				),
+			},
+			want: wants{
+				firstWeek: []models.InlineKeyboardButton{
+					{Text: "(29)", CallbackData: "calendar/shs_29.05.2023"},
+					{Text: "(30)", CallbackData: "calendar/shs_30.05.2023"},
+					{Text: "(31)", CallbackData: "calendar/shs_31.05.2023"},
+					{Text: "1", CallbackData: "calendar/sed_01.06.2023"},
+					{Text: "2", CallbackData: "calendar/sed_02.06.2023"},
+					{Text: "3", CallbackData: "calendar/sed_03.06.2023"},
+					{Text: "4", CallbackData: "calendar/sed_04.06.2023"},
+				},
+				lastWeek: []models.InlineKeyboardButton{
+					{Text: "26", CallbackData: "calendar/sed_26.06.2023"},
+					{Text: "27", CallbackData: "calendar/sed_27.06.2023"},
+					{Text: "28", CallbackData: "calendar/sed_28.06.2023"},
+					{Text: "29", CallbackData: "calendar/sed_29.06.2023"},
+					{Text: "30", CallbackData: "calendar/sed_30.06.2023"},
+					{Text: "(1❌)", CallbackData: "calendar/shs_01.07.2023"},
+					{Text: "(2❌)", CallbackData: "calendar/shs_02.07.2023"},
+				},
+			},
+		},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			kf, _ := newDefaultKeyboardFormer().ApplyNewOptions(tt.options...).(*KeyboardFormer)
+			weeks := kf.GenerateCurrentMonth(6, 2023, currentTime)
+			if !isSlicesEqual(weeks[0], tt.want.firstWeek) {
+				t.Errorf("unexpected first week: got: %v, want: %v", weeks[0], tt.want.firstWeek)
+			}
+			if !isSlicesEqual(weeks[len(weeks)-1], tt.want.lastWeek) {
+				t.Errorf("unexpected last week: got: %v, want: %v", weeks[len(weeks)-1], tt.want.lastWeek)
+			}
+		},
+		)
+	}
+}
+
+func TestAdjacentDayNavigateOpensItsMonth(t *testing.T) {
+	t.Parallel()
+
+	currentTime := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+	kf, _ := newDefaultKeyboardFormer().ApplyNewOptions(ChangeAdjacentDaysMode(AdjacentDaysNavigate)).(*KeyboardFormer)
+
+	response := kf.GenerateCalendarKeyboard("calendar/shs_01.07.2023", currentTime)
+	if !response.SelectedDay.IsZero() {
+		t.Errorf("unexpected selected day: %v", response.SelectedDay)
+	}
+	wantHeader := kf.GenerateCalendar(7, 2023, currentTime).InlineKeyboard[0]
+	if !isSlicesEqual(response.InlineKeyboardMarkup.InlineKeyboard[0], wantHeader) {
+		t.Errorf("unexpected header: got: %v, want: %v", response.InlineKeyboardMarkup.InlineKeyboard[0], wantHeader)
+	}
+}
@@ -3,6 +3,8 @@ package generator
 import (
 	"time"
 
+	"github.com/thevan4/telegram-calendar/day_button_former"
+	"github.com/thevan4/telegram-calendar/models"
 	"github.com/thevan4/telegram-calendar/payload_former"
 )
 
@@ -15,14 +17,40 @@ type FlatConfig struct {
 	MonthNames                 [12]string
 	HomeButtonForBeauty        string
 	PayloadEncoderDecoder      payload_former.PayloadEncoderDecoder
+	RightToLeft                bool
+	NumeralSystem              day_button_former.NumeralSystem
+	FooterButtons              []FooterButton
+	ExtraRowsAbove             [][]models.InlineKeyboardButton
+	ExtraRowsBelow             [][]models.InlineKeyboardButton
+	HeaderLayout               HeaderLayout
+	HideHomeButton             bool
+	PrevMonthName              string
+	NextMonthName              string
+	PrevYearName               string
+	NextYearName               string
+	AdjacentDaysMode           AdjacentDaysMode
+	EventSource                EventSource
+	EventMarker                string
+	ShowEventsCount            bool
+	FixedWeeksRows             bool
+	HideDaysNames              bool
 	PrefixForCurrentDay        string
 	PostfixForCurrentDay       string
 	PrefixForNonSelectedDay    string
 	PostfixForNonSelectedDay   string
 	PrefixForPickDay           string
 	PostfixForPickDay          string
+	PrefixForAdjacentDay       string
+	PostfixForAdjacentDay      string
+	PrefixForPreselectedDay    string
+	PostfixForPreselectedDay   string
 	UnselectableDaysBeforeTime time.Time
 	UnselectableDaysAfterTime  time.Time
 	UnselectableDays           map[time.Time]struct{}
 	Timezone                   time.Location
+	MinimumLeadTime            time.Duration
+	DayDecorators              []day_button_former.DayDecorator
+	LoadProvider               day_button_former.LoadProvider
+	LoadLevels                 []day_button_former.LoadLevel
+	FullLoadThreshold          float64
 }
@@ -0,0 +1,169 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/thevan4/telegram-calendar/day_button_former"
+)
+
+// KeyboardGeneratorWrapper is a decorator of another KeyboardGenerator. The options are applied to the wrapped one,
+// so they work through any number of decorators.
+type KeyboardGeneratorWrapper interface {
+	KeyboardGenerator
+	Unwrap() KeyboardGenerator
+	// WithWrapped returns the same decorator around wrapped, the receiver is not changed.
+	WithWrapped(wrapped KeyboardGenerator) KeyboardGenerator
+}
+
+// ConfigurableKeyboardGenerator is an own KeyboardGenerator that keeps the FlatConfig settings (from GetCurrentConfig),
+// the options change them.
+type ConfigurableKeyboardGenerator interface {
+	KeyboardGenerator
+	// WithConfig returns the copy with the settings, the receiver is not changed.
+	WithConfig(config FlatConfig) KeyboardGenerator
+}
+
+// ErrUnsupportedOption the option does nothing to the generator implementation.
+var ErrUnsupportedOption = day_button_former.ErrUnsupportedOption
+
+// UnsupportedOptionError the option at Index does nothing, see CheckOptions.
+type UnsupportedOptionError = day_button_former.UnsupportedOptionError
+
+// NewKeyboardFormerFromConfig the generator with the settings, GetCurrentConfig returns them back.
+// ConfigurableKeyboardGenerator implementations may keep one to render the keyboards.
+func NewKeyboardFormerFromConfig(config FlatConfig) KeyboardGenerator {
+	return newKeyboardFormerFromConfig(config)
+}
+
+func newKeyboardFormerFromConfig(config FlatConfig) *KeyboardFormer {
+	return &KeyboardFormer{
+		yearsBackForChoose:    config.YearsBackForChoose,
+		yearsForwardForChoose: config.YearsForwardForChoose,
+		sumYearsForChoose:     config.SumYearsForChoose,
+		daysNames:             config.DaysNames,
+		monthNames:            config.MonthNames,
+		homeButtonForBeauty:   config.HomeButtonForBeauty,
+		hideHomeButton:        config.HideHomeButton,
+		headerLayout:          config.HeaderLayout,
+		prevMonthName:         config.PrevMonthName,
+		nextMonthName:         config.NextMonthName,
+		prevYearName:          config.PrevYearName,
+		nextYearName:          config.NextYearName,
+		payloadEncoderDecoder: config.PayloadEncoderDecoder,
+		buttonsTextWrapper:    day_button_former.NewButtonsFormerFromConfig(config.dayButtonsConfig()),
+		rightToLeft:           config.RightToLeft,
+		footerButtons:         append([]FooterButton(nil), config.FooterButtons...),
+		extraRowsAbove:        copyRows(config.ExtraRowsAbove),
+		extraRowsBelow:        copyRows(config.ExtraRowsBelow),
+		adjacentDaysMode:      config.AdjacentDaysMode,
+		fixedWeeksRows:        config.FixedWeeksRows,
+		hideDaysNames:         config.HideDaysNames,
+		eventSource:           config.EventSource,
+		eventMarker:           config.EventMarker,
+		showEventsCount:       config.ShowEventsCount,
+	}
+}
+
+func (c FlatConfig) dayButtonsConfig() day_button_former.FlatConfig {
+	return day_button_former.FlatConfig{
+		PrefixForCurrentDay:        c.PrefixForCurrentDay,
+		PostfixForCurrentDay:       c.PostfixForCurrentDay,
+		PrefixForNonSelectedDay:    c.PrefixForNonSelectedDay,
+		PostfixForNonSelectedDay:   c.PostfixForNonSelectedDay,
+		PrefixForPickDay:           c.PrefixForPickDay,
+		PostfixForPickDay:          c.PostfixForPickDay,
+		PrefixForAdjacentDay:       c.PrefixForAdjacentDay,
+		PostfixForAdjacentDay:      c.PostfixForAdjacentDay,
+		PrefixForPreselectedDay:    c.PrefixForPreselectedDay,
+		PostfixForPreselectedDay:   c.PostfixForPreselectedDay,
+		UnselectableDaysBeforeTime: c.UnselectableDaysBeforeTime,
+		UnselectableDaysAfterTime:  c.UnselectableDaysAfterTime,
+		UnselectableDays:           c.UnselectableDays,
+		Timezone:                   c.Timezone,
+		NumeralSystem:              c.NumeralSystem,
+		MinimumLeadTime:            c.MinimumLeadTime,
+		DayDecorators:              c.DayDecorators,
+		LoadProvider:               c.LoadProvider,
+		LoadLevels:                 c.LoadLevels,
+		FullLoadThreshold:          c.FullLoadThreshold,
+	}
+}
+
+// ApplyOptions applies the options to kg one by one, own implementations may use it for ApplyNewOptions.
+// Like ApplyNewOptions of KeyboardFormer, kg itself is not changed.
+func ApplyOptions(kg KeyboardGenerator, options ...func(KeyboardGenerator) KeyboardGenerator) KeyboardGenerator {
+	if k, ok := kg.(*KeyboardFormer); ok {
+		return k.ApplyNewOptions(options...)
+	}
+	for _, option := range options {
+		kg = option(kg)
+	}
+	return kg
+}
+
+// CheckOptions reports the options that would do nothing to kg, each one as *UnsupportedOptionError.
+// The options of this package support *KeyboardFormer, KeyboardGeneratorWrapper and ConfigurableKeyboardGenerator,
+// the days buttons options of ApplyNewOptionsForButtonsTextWrapper are checked against the days buttons former too.
+// kg is not changed. Options of other packages are not checked.
+func CheckOptions(kg KeyboardGenerator, options ...func(KeyboardGenerator) KeyboardGenerator) error {
+	var errs []error
+	for i, option := range options {
+		probe := &optionsProbe{KeyboardGenerator: kg}
+		option(probe)
+		if probe.unsupported != nil {
+			errs = append(errs, &UnsupportedOptionError{Index: i, Err: probe.unsupported})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Wraps the checked generator, the options are applied to a copy and the unsupported one is recorded.
+type optionsProbe struct {
+	KeyboardGenerator
+	unsupported error
+}
+
+// Applies the option to kg: *KeyboardFormer is changed in place (options get a copy from ApplyNewOptions),
+// the wrapped ones are copied first, because the decorator shares them.
+func configure(kg KeyboardGenerator, apply func(k *KeyboardFormer)) KeyboardGenerator {
+	return configureWith(kg, apply, nil, false, nil)
+}
+
+// check, if not nil, reports the options nested in the option, it runs for CheckOptions only.
+func configureWith(
+	kg KeyboardGenerator,
+	apply func(k *KeyboardFormer),
+	check func(k *KeyboardFormer) error,
+	copyFormer bool,
+	unsupported *error,
+) KeyboardGenerator {
+	switch k := kg.(type) {
+	case *KeyboardFormer:
+		if copyFormer {
+			k = k.clone()
+		}
+		if check != nil && unsupported != nil {
+			*unsupported = check(k)
+		}
+		apply(k)
+		return k
+	case *optionsProbe:
+		k.KeyboardGenerator = configureWith(k.KeyboardGenerator, apply, check, true, &k.unsupported)
+		return k
+	case KeyboardGeneratorWrapper:
+		return k.WithWrapped(configureWith(k.Unwrap(), apply, check, true, unsupported))
+	case ConfigurableKeyboardGenerator:
+		kf := newKeyboardFormerFromConfig(k.GetCurrentConfig())
+		if check != nil && unsupported != nil {
+			*unsupported = check(kf)
+		}
+		apply(kf)
+		return k.WithConfig(kf.GetCurrentConfig())
+	default:
+		if unsupported != nil {
+			*unsupported = fmt.Errorf("%w by %T", ErrUnsupportedOption, kg)
+		}
+		return kg
+	}
+}
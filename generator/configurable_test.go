@@ -0,0 +1,212 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/thevan4/telegram-calendar/day_button_former"
+	"github.com/thevan4/telegram-calendar/models"
+)
+
+// Decorator that counts the renders.
+type countingGenerator struct {
+	KeyboardGenerator
+	renders *atomic.Int64
+}
+
+func (cg countingGenerator) GenerateCalendarKeyboard(
+	callbackPayload string,
+	currentTime time.Time,
+) models.GenerateCalendarKeyboardResponse {
+	return cg.GenerateCalendarKeyboardWithOptions(callbackPayload, currentTime)
+}
+
+func (cg countingGenerator) GenerateCalendarKeyboardWithOptions(
+	callbackPayload string,
+	currentTime time.Time,
+	renderOptions ...RenderOption,
+) models.GenerateCalendarKeyboardResponse {
+	cg.renders.Add(1)
+	return GenerateCalendarKeyboardWithOptions(cg.KeyboardGenerator, callbackPayload, currentTime, renderOptions...)
+}
+
+func (cg countingGenerator) ApplyNewOptions(options ...func(KeyboardGenerator) KeyboardGenerator) KeyboardGenerator {
+	return ApplyOptions(cg, options...)
+}
+
+func (cg countingGenerator) Unwrap() KeyboardGenerator {
+	return cg.KeyboardGenerator
+}
+
+func (cg countingGenerator) WithWrapped(wrapped KeyboardGenerator) KeyboardGenerator {
+	cg.KeyboardGenerator = wrapped
+	return cg
+}
+
+// Own generator that keeps the settings.
+type configGenerator struct {
+	config FlatConfig
+}
+
+func (cg configGenerator) GenerateCalendarKeyboard(
+	callbackPayload string,
+	currentTime time.Time,
+) models.GenerateCalendarKeyboardResponse {
+	return NewKeyboardFormerFromConfig(cg.config).GenerateCalendarKeyboard(callbackPayload, currentTime)
+}
+
+func (cg configGenerator) ApplyNewOptions(options ...func(KeyboardGenerator) KeyboardGenerator) KeyboardGenerator {
+	return ApplyOptions(cg, options...)
+}
+
+func (cg configGenerator) GetUnselectableDays() map[time.Time]struct{} {
+	return cg.config.UnselectableDays
+}
+
+func (cg configGenerator) GetCurrentConfig() FlatConfig {
+	return cg.config
+}
+
+func (cg configGenerator) GetTimezone() time.Location {
+	return cg.config.Timezone
+}
+
+func (cg configGenerator) WithConfig(config FlatConfig) KeyboardGenerator {
+	return configGenerator{config: config}
+}
+
+// Neither a wrapper nor configurable.
+type opaqueGenerator struct {
+	KeyboardGenerator
+}
+
+func TestNewKeyboardFormerFromConfig(t *testing.T) {
+	t.Parallel()
+
+	kf := NewKeyboardFormer(
+		ChangeYearsBackForChoose(2),
+		ChangeHeaderLayout(HeaderLayoutTwoRows),
+		ChangeFooterButtons(FooterButton{Action: FooterActionToday}),
+		ChangeNumeralSystem(day_button_former.NumeralsThai),
+		ApplyNewOptionsForButtonsTextWrapper(
+			day_button_former.ChangeTimezone(time.FixedZone("UTC+3", 3*60*60)),
+			day_button_former.ChangeUnselectableDays(map[time.Time]struct{}{time.Date(2023, 6, 12, 0, 0, 0, 0, time.UTC): {}}),
+			day_button_former.ChangeMinimumLeadTime(time.Hour),
+			day_button_former.ChangePostfixForPickDay("!"),
+		),
+	)
+
+	config := kf.GetCurrentConfig()
+	got := NewKeyboardFormerFromConfig(config).GetCurrentConfig()
+
+	// Map keys are compared with ==, that is with the location pointer, so the days are compared apart.
+	if gotDays, wantDays := fmt.Sprint(got.UnselectableDays), fmt.Sprint(config.UnselectableDays); gotDays != wantDays {
+		t.Errorf("unselectable days are changed: got %v, want %v", gotDays, wantDays)
+	}
+	got.UnselectableDays, config.UnselectableDays = nil, nil
+	if !reflect.DeepEqual(got, config) {
+		t.Errorf("config is changed: got %+v, want %+v", got, config)
+	}
+}
+
+func TestOptionsForOwnImplementations(t *testing.T) {
+	t.Parallel()
+
+	options := []func(KeyboardGenerator) KeyboardGenerator{
+		ChangeYearsBackForChoose(2),
+		ChangeHomeButtonForBeauty("🏠"),
+		ApplyNewOptionsForButtonsTextWrapper(day_button_former.ChangePostfixForNonSelectedDay("🚫")),
+	}
+
+	tests := []struct {
+		name      string
+		generator KeyboardGenerator
+	}{
+		{
+			name:      "wrapper",
+			generator: countingGenerator{KeyboardGenerator: NewKeyboardFormer(), renders: &atomic.Int64{}},
+		},
+		{
+			name: "wrapper of wrapper",
+			generator: countingGenerator{
+				KeyboardGenerator: countingGenerator{KeyboardGenerator: NewKeyboardFormer(), renders: &atomic.Int64{}},
+				renders:           &atomic.Int64{},
+			},
+		},
+		{
+			name:      "configurable",
+			generator: configGenerator{config: NewKeyboardFormer().GetCurrentConfig()},
+		},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := CheckOptions(tt.generator, options...); err != nil {
+				t.Errorf("unexpected CheckOptions error: %v", err)
+			}
+
+			before := tt.generator.GetCurrentConfig()
+			changed := tt.generator.ApplyNewOptions(options...)
+			if reflect.TypeOf(changed) != reflect.TypeOf(tt.generator) {
+				t.Errorf("generator type is changed: got %T, want %T", changed, tt.generator)
+			}
+			if after := tt.generator.GetCurrentConfig(); !reflect.DeepEqual(after, before) {
+				t.Errorf("source generator is changed: %+v", after)
+			}
+
+			config := changed.GetCurrentConfig()
+			if config.YearsBackForChoose != 2 || config.HomeButtonForBeauty != "🏠" || config.PostfixForNonSelectedDay != "🚫" {
+				t.Errorf("options are not applied: %+v", config)
+			}
+			home := changed.GenerateCalendarKeyboard("", time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)).InlineKeyboardMarkup.InlineKeyboard[0][3]
+			if home.Text != "🏠" {
+				t.Errorf("unexpected home button: %v", home.Text)
+			}
+		},
+		)
+	}
+}
+
+func TestCheckOptionsReportsUnsupported(t *testing.T) {
+	t.Parallel()
+
+	opaque := opaqueGenerator{KeyboardGenerator: NewKeyboardFormer()}
+	err := CheckOptions(opaque, ChangeYearsBackForChoose(1), ChangeHomeButtonForBeauty("🏠"))
+
+	var joined interface{ Unwrap() []error }
+	if !errors.As(err, &joined) || len(joined.Unwrap()) != 2 || !errors.Is(err, ErrUnsupportedOption) {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	var optionErr *UnsupportedOptionError
+	if !errors.As(joined.Unwrap()[1], &optionErr) || optionErr.Index != 1 {
+		t.Errorf("unexpected option error: %v", joined.Unwrap()[1])
+	}
+
+	// Days buttons options are checked against the days buttons former.
+	kf := NewKeyboardFormer(ChangeDaysButtonsText(opaqueDaysButtonsText{DaysButtonsText: day_button_former.NewButtonsFormer()}))
+	err = CheckOptions(kf,
+		ChangeHomeButtonForBeauty("🏠"),
+		ApplyNewOptionsForButtonsTextWrapper(day_button_former.ChangePrefixForPickDay(">")),
+	)
+	if !errors.As(err, &optionErr) || optionErr.Index != 1 || !errors.Is(err, ErrUnsupportedOption) {
+		t.Errorf("unexpected days buttons option error: %v", err)
+	}
+}
+
+// Neither a wrapper nor configurable.
+type opaqueDaysButtonsText struct {
+	day_button_former.DaysButtonsText
+}
+
+func (odbt opaqueDaysButtonsText) ApplyNewOptions(
+	_ ...func(day_button_former.DaysButtonsText) day_button_former.DaysButtonsText,
+) day_button_former.DaysButtonsText {
+	return odbt
+}
@@ -3,7 +3,7 @@ package generator
 import "time"
 
 const (
-	// Navigation actions.
+	// Navigation actions, names are the defaults.
 	prevMonthAction     = "prm"
 	prevMonthActionName = "<"
 	nextMonthAction     = "nem"
@@ -23,9 +23,22 @@ const (
 	silentDoNothingAction   = "sdn"
 	goToDefaultKeyboard     = ""
 	unselectableDaySelected = "uds"
+	// Show the events of the day.
+	eventsDayAction = "evd"
+
+	// Footer actions.
+	todayAction              = "tdy"
+	todayActionNameDefault   = "Today"
+	clearAction              = "clr"
+	clearActionNameDefault   = "Clear"
+	cancelAction             = "cnl"
+	cancelActionNameDefault  = "Cancel"
+	confirmAction            = "cfm"
+	confirmActionNameDefault = "Confirm"
 
 	emptyText            = " "
 	daysInWeek           = 7
+	maxWeeksInMonth      = 6
 	standardButtonsAtRow = 7
 	maxSumYearsForChoose = 6 // more than 6 does not look good.
 	hoursInDay           = 24 * time.Hour
@@ -38,9 +51,42 @@ const (
 	yearsForwardForChooseDefault = 3
 	sumYearsForChooseDefault     = 3
 	emojiForBeautyDefault        = "🏩"
+	eventMarkerDefault           = "•"
+
+	languageCodeEnglish     = "en"
+	languageCodeRussian     = "ru"
+	languageRegionSeparator = "-"
 )
 
 var (
 	daysNamesDefault  = [7]string{"Mo", "Tu", "We", "Th", "Fr", "Sa", "Su"}                                            //nolint:lll,nolintlint,gochecknoglobals
 	monthNamesDefault = [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"} //nolint:lll,nolintlint,gochecknoglobals
+	daysNamesRussian  = [7]string{"Пн", "Вт", "Ср", "Чт", "Пт", "Сб", "Вс"}                                            //nolint:lll,nolintlint,gochecknoglobals
+	monthNamesRussian = [12]string{"Янв", "Фев", "Мар", "Апр", "Май", "Июн", "Июл", "Авг", "Сен", "Окт", "Ноя", "Дек"} //nolint:lll,nolintlint,gochecknoglobals
+)
+
+const (
+	// Timezone picker actions.
+	timezoneCallback           = "timezone"
+	showTimezoneRegionsAction  = "rgs"
+	showTimezoneRegionAction   = "rgn"
+	selectTimezoneAction       = "sez"
+	showUTCOffsetsAction       = "ofs"
+	selectUTCOffsetAction      = "seo"
+	timezoneDoNothingAction    = "sdn"
+	prevTimezonePageActionName = "«"
+	nextTimezonePageActionName = "»"
+
+	timezoneRegionsAtRow          = 2
+	timezoneCitiesAtRow           = 2
+	utcOffsetsAtRow               = 4
+	timezoneCitiesPerPageDefault  = 12
+	timezoneBackButtonNameDefault = "↩"
+	utcOffsetsButtonNameDefault   = "UTC±"
+	utcName                       = "UTC"
+	utcOffsetBiasMinutes          = 720 // keeps encoded offsets non-negative.
+	minUTCOffsetMinutes           = -720
+	maxUTCOffsetMinutes           = 840
+	minutesInHour                 = 60
+	secondsInMinute               = 60
 )
@@ -0,0 +1,169 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/thevan4/telegram-calendar/day_button_former"
+	"github.com/thevan4/telegram-calendar/models"
+)
+
+type ctxKeyAtGenerator struct{}
+
+var errNoConnection = errors.New("no connection at the context")
+
+// Event source that requires the context value, like a database lookup with the request scoped connection.
+func contextEventSource(lookups *atomic.Int64) EventSourceContextFunc {
+	source := NewMemoryEventSource(models.Event{ID: "1", Title: "Standup", Start: time.Date(2023, 6, 5, 9, 0, 0, 0, time.UTC)})
+	return func(ctx context.Context, year int, month time.Month, location *time.Location) ([]models.Event, error) {
+		lookups.Add(1)
+		if ctx.Value(ctxKeyAtGenerator{}) == nil {
+			return nil, errNoConnection
+		}
+		return source.EventsForMonth(year, month, location)
+	}
+}
+
+// Texts of the June 2023 days buttons, 1 June is Thursday.
+func juneDaysTexts(keyboard models.InlineKeyboardMarkup) []string {
+	var texts []string
+	for _, row := range keyboard.InlineKeyboard[2:7] {
+		for _, button := range row {
+			if strings.TrimSpace(button.Text) != "" {
+				texts = append(texts, button.Text)
+			}
+		}
+	}
+	return texts
+}
+
+func TestGenerateCalendarKeyboardContext(t *testing.T) {
+	t.Parallel()
+
+	ct62023 := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	valueCtx := context.WithValue(context.Background(), ctxKeyAtGenerator{}, "connection")
+	cancelledCtx, cancel := context.WithCancel(valueCtx)
+	cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context //nolint:containedctx // test case.
+		// Decorated with countingGenerator, that has no GenerateCalendarKeyboardContext.
+		decorated   bool
+		wantLookups int64
+		wantMarker  bool
+		wantErr     error
+	}{
+		{name: "context value", ctx: valueCtx, wantLookups: 1, wantMarker: true},
+		{name: "decorated", ctx: valueCtx, decorated: true, wantLookups: 1, wantMarker: true},
+		{name: "no context value", ctx: context.Background(), wantLookups: 1, wantErr: errNoConnection},
+		{name: "cancelled", ctx: cancelledCtx, wantErr: context.Canceled},
+		{name: "decorated cancelled", ctx: cancelledCtx, decorated: true, wantErr: context.Canceled},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var lookups atomic.Int64
+			kg := NewKeyboardFormer(ChangeEventSource(contextEventSource(&lookups)))
+			if tt.decorated {
+				kg = countingGenerator{KeyboardGenerator: kg, renders: &atomic.Int64{}}
+			}
+
+			response, err := GenerateCalendarKeyboardContext(tt.ctx, kg, "", ct62023)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("unexpected error: got: %v, want: %v", err, tt.wantErr)
+			}
+			if gotLookups := lookups.Load(); gotLookups != tt.wantLookups {
+				t.Errorf("unexpected lookups: got: %v, want: %v", gotLookups, tt.wantLookups)
+			}
+			// The keyboard is rendered even for the cancelled context, without the lookups.
+			texts := juneDaysTexts(response.InlineKeyboardMarkup)
+			if len(texts) != 30 {
+				t.Errorf("unexpected days: %v", texts)
+				return
+			}
+			if gotMarker := texts[4] == "5•"; gotMarker != tt.wantMarker {
+				t.Errorf("unexpected event marker: got: %v, want: %v", texts[4], tt.wantMarker)
+			}
+		},
+		)
+	}
+}
+
+func TestGenerateCalendarKeyboardContextPartial(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// The deadline comes at the day 10 lookup.
+	loadProvider := day_button_former.LoadProviderContextFunc(func(_ context.Context, date time.Time) (float64, bool, error) {
+		if date.Day() == 10 {
+			cancel()
+		}
+		return 0, true, nil
+	})
+	kg, _ := NewKeyboardFormer(
+		ApplyNewOptionsForButtonsTextWrapper(day_button_former.ChangeLoadProvider(loadProvider)),
+	).(ContextKeyboardGenerator)
+
+	response, err := kg.GenerateCalendarKeyboardContext(ctx, "", time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	texts := juneDaysTexts(response.InlineKeyboardMarkup)
+	// The days after the deadline have the unknown load, they are unselectable.
+	if len(texts) != 30 || texts[9] != "🟢10" || texts[10] != "11❌" {
+		t.Errorf("unexpected partial keyboard days: %v", texts)
+	}
+}
+
+func TestGenerateCalendarKeyboardContextProvidersErrors(t *testing.T) {
+	t.Parallel()
+
+	errLookup := errors.New("lookup failed")
+	loadProvider := day_button_former.LoadProviderContextFunc(func(_ context.Context, date time.Time) (float64, bool, error) {
+		if date.Day() == 10 || date.Day() == 11 {
+			return 0, false, errLookup
+		}
+		return 0, true, nil
+	})
+	kg := NewKeyboardFormer(
+		ChangeEventSource(failingEventSource{}),
+		ApplyNewOptionsForButtonsTextWrapper(day_button_former.ChangeLoadProvider(loadProvider)),
+	)
+
+	response, err := GenerateCalendarKeyboardContext(context.Background(), kg, "", time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC))
+	if !errors.Is(err, errLookup) || !strings.Contains(err.Error(), "source is unavailable") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	// The same error of the two days is joined once.
+	if strings.Count(err.Error(), errLookup.Error()) != 1 {
+		t.Errorf("repeated error: %v", err)
+	}
+	texts := juneDaysTexts(response.InlineKeyboardMarkup)
+	if len(texts) != 30 || texts[9] != "10❌" || texts[10] != "11❌" || texts[11] != "🟢12" {
+		t.Errorf("unexpected days: %v", texts)
+	}
+}
+
+func TestGenerateCalendarKeyboardIsContextWrapper(t *testing.T) {
+	t.Parallel()
+
+	kg, _ := NewKeyboardFormer(ChangeFooterButtons(FooterButton{Action: FooterActionToday})).(ContextKeyboardGenerator)
+	ct62023 := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, payload := range []string{"", "calendar/nem_00.06.2023", "calendar/sed_12.06.2023", "calendar/tdy_00.00.0000"} {
+		want := GenerateCalendarKeyboardWithOptions(kg, payload, ct62023, WithRightToLeft(true))
+		got, err := kg.GenerateCalendarKeyboardContext(context.Background(), payload, ct62023, WithRightToLeft(true))
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("%v: context render differs: got %+v, %v, want %+v", payload, got, err, want)
+		}
+	}
+}
@@ -1,14 +1,17 @@
 package generator
 
 import (
-	"strconv"
+	"context"
+	"errors"
 	"time"
 
 	"github.com/thevan4/telegram-calendar/day_button_former"
 	"github.com/thevan4/telegram-calendar/models"
+	"github.com/thevan4/telegram-calendar/tracing"
 )
 
 // KeyboardGenerator ...
+// ApplyNewOptions must return a new generator and leave the receiver as is, the manager shares it between renders.
 type KeyboardGenerator interface {
 	GenerateCalendarKeyboard(callbackPayload string, currentTime time.Time) models.GenerateCalendarKeyboardResponse
 	ApplyNewOptions(options ...func(KeyboardGenerator) KeyboardGenerator) KeyboardGenerator
@@ -17,6 +20,61 @@ type KeyboardGenerator interface {
 	GetTimezone() time.Location
 }
 
+// RenderOptionsKeyboardGenerator KeyboardGenerator that takes the render options (see RenderOption).
+type RenderOptionsKeyboardGenerator interface {
+	KeyboardGenerator
+	GenerateCalendarKeyboardWithOptions(
+		callbackPayload string,
+		currentTime time.Time,
+		renderOptions ...RenderOption,
+	) models.GenerateCalendarKeyboardResponse
+}
+
+// GenerateCalendarKeyboardWithOptions renders with the options by RenderOptionsKeyboardGenerator,
+// other generators render without them.
+func GenerateCalendarKeyboardWithOptions(
+	kg KeyboardGenerator,
+	callbackPayload string,
+	currentTime time.Time,
+	renderOptions ...RenderOption,
+) models.GenerateCalendarKeyboardResponse {
+	if rkg, ok := kg.(RenderOptionsKeyboardGenerator); ok {
+		return rkg.GenerateCalendarKeyboardWithOptions(callbackPayload, currentTime, renderOptions...)
+	}
+	return kg.GenerateCalendarKeyboard(callbackPayload, currentTime)
+}
+
+// ContextKeyboardGenerator KeyboardGenerator that passes the context to the providers (see ContextEventSource).
+// The context may be passed with the WithContext render option as well, so decorators that pass the render options
+// through keep it; GenerateCalendarKeyboardContext (the function) does so for the generators without this method.
+type ContextKeyboardGenerator interface {
+	KeyboardGenerator
+	// GenerateCalendarKeyboardContext returns ctx.Err() if the context is done: the keyboard is rendered without
+	// the providers lookups that were not made (no events markers and load markers, for example).
+	GenerateCalendarKeyboardContext(
+		ctx context.Context,
+		callbackPayload string,
+		currentTime time.Time,
+		renderOptions ...RenderOption,
+	) (models.GenerateCalendarKeyboardResponse, error)
+}
+
+// GenerateCalendarKeyboardContext renders with ctx by ContextKeyboardGenerator, other generators get ctx
+// with the WithContext render option (see GenerateCalendarKeyboardWithOptions).
+func GenerateCalendarKeyboardContext(
+	ctx context.Context,
+	kg KeyboardGenerator,
+	callbackPayload string,
+	currentTime time.Time,
+	renderOptions ...RenderOption,
+) (models.GenerateCalendarKeyboardResponse, error) {
+	if ckg, ok := kg.(ContextKeyboardGenerator); ok {
+		return ckg.GenerateCalendarKeyboardContext(ctx, callbackPayload, currentTime, renderOptions...)
+	}
+	renderOptions = append([]RenderOption{WithContext(ctx)}, renderOptions...)
+	return GenerateCalendarKeyboardWithOptions(kg, callbackPayload, currentTime, renderOptions...), ctx.Err()
+}
+
 // Generator ...
 type Generator interface {
 	GenerateGoToPrevMonth(month, year int, currentTime time.Time) models.InlineKeyboardMarkup
@@ -34,10 +92,95 @@ type Generator interface {
 func (k *KeyboardFormer) GenerateCalendarKeyboard(
 	callbackPayload string,
 	currentTime time.Time,
+) models.GenerateCalendarKeyboardResponse {
+	return k.GenerateCalendarKeyboardWithOptions(callbackPayload, currentTime)
+}
+
+// GenerateCalendarKeyboardWithOptions GenerateCalendarKeyboard with the render options, they are applied
+// to this call only.
+func (k *KeyboardFormer) GenerateCalendarKeyboardWithOptions(
+	callbackPayload string,
+	currentTime time.Time,
+	renderOptions ...RenderOption,
+) models.GenerateCalendarKeyboardResponse {
+	response, _ := k.render(NewRenderSettings(renderOptions...), callbackPayload, currentTime)
+	return response
+}
+
+// GenerateCalendarKeyboardContext GenerateCalendarKeyboard with the context for the providers,
+// see ContextKeyboardGenerator.
+func (k *KeyboardFormer) GenerateCalendarKeyboardContext(
+	ctx context.Context,
+	callbackPayload string,
+	currentTime time.Time,
+	renderOptions ...RenderOption,
+) (models.GenerateCalendarKeyboardResponse, error) {
+	rs := NewRenderSettings(append([]RenderOption{WithContext(ctx)}, renderOptions...)...)
+	return k.render(rs, callbackPayload, currentTime)
+}
+
+func (k *KeyboardFormer) render(
+	rs RenderSettings,
+	callbackPayload string,
+	currentTime time.Time,
+) (models.GenerateCalendarKeyboardResponse, error) {
+	kf := k.withRenderSettings(rs)
+	if kf.traced {
+		endRenderSpan := kf.startRenderSpan(callbackPayload)
+		defer endRenderSpan()
+	}
+
+	response := kf.generateCalendarKeyboard(callbackPayload, currentTime)
+	if kf.renderCtx != nil {
+		kf.renderErrs.add(kf.renderCtx.Err())
+		return response, kf.renderErrs.join()
+	}
+	return response, nil
+}
+
+// The providers errors of one render, the same errors (ctx.Err() of every lookup, for example) are kept once.
+type renderErrors struct {
+	errs []error
+}
+
+func (r *renderErrors) add(err error) {
+	if r == nil || err == nil {
+		return
+	}
+	for _, known := range r.errs {
+		if errors.Is(known, err) || known.Error() == err.Error() {
+			return
+		}
+	}
+	r.errs = append(r.errs, err)
+}
+
+func (r *renderErrors) join() error {
+	return errors.Join(r.errs...)
+}
+
+func (k *KeyboardFormer) generateCalendarKeyboard(
+	callbackPayload string,
+	currentTime time.Time,
+) models.GenerateCalendarKeyboardResponse {
+	incomePayload := k.payloadEncoderDecoder.Decoding(callbackPayload)
+	response := k.generateCalendarKeyboardForPayload(incomePayload, currentTime)
+	response.Action = payloadActionName(callbackPayload, incomePayload.Action)
+	return response
+}
+
+func (k *KeyboardFormer) generateCalendarKeyboardForPayload(
+	incomePayload models.PayloadData,
+	currentTime time.Time,
 ) models.GenerateCalendarKeyboardResponse {
 	var selectedDay time.Time
 	timeZone := k.GetTimezone()
-	incomePayload := k.payloadEncoderDecoder.Decoding(callbackPayload)
+	if k.userLocation != nil {
+		currentTime = currentTime.In(k.userLocation)
+		if k.selectedDayInUserLocation {
+			timeZone = *k.userLocation
+		}
+	}
 
 	switch incomePayload.Action {
 	case prevMonthAction:
@@ -82,6 +225,23 @@ func (k *KeyboardFormer) GenerateCalendarKeyboard(
 			SelectedDay: day_button_former.FormDateTime(incomePayload.CalendarDay, incomePayload.CalendarMonth,
 				incomePayload.CalendarYear, &timeZone),
 		}
+	case eventsDayAction:
+		return k.selectEventsDay(incomePayload.CalendarDay, incomePayload.CalendarMonth, incomePayload.CalendarYear)
+	case todayAction:
+		return k.selectToday(currentTime, &timeZone)
+	case clearAction:
+		return models.GenerateCalendarKeyboardResponse{
+			InlineKeyboardMarkup: k.GenerateCalendar(incomePayload.CalendarMonth, incomePayload.CalendarYear, currentTime),
+			IsCleared:            true,
+		}
+	case cancelAction:
+		return models.GenerateCalendarKeyboardResponse{
+			IsCancelled: true,
+		}
+	case confirmAction:
+		return models.GenerateCalendarKeyboardResponse{
+			IsConfirmed: true,
+		}
 	case unselectableDaySelected:
 		return models.GenerateCalendarKeyboardResponse{
 			SelectedDay: day_button_former.FormDateTime(incomePayload.CalendarDay, incomePayload.CalendarMonth,
@@ -132,47 +292,52 @@ func (k *KeyboardFormer) GenerateGoToNextYear(month, year int, currentTime time.
 
 // GenerateSelectMonths ...
 func (k *KeyboardFormer) GenerateSelectMonths(month, year int, currentTime time.Time) (keyboard models.InlineKeyboardMarkup) {
-	keyboard.InlineKeyboard = make([][]models.InlineKeyboardButton, 0, twoRowsForMonth)
+	keyboard.InlineKeyboard = make([][]models.InlineKeyboardButton, 0, twoRowsForMonth+len(k.extraRowsAbove)+len(k.extraRowsBelow))
 
-	monthYearRow := k.generateMonthYearRow(month, year, currentTime, true, false)
-	keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, monthYearRow)
+	headerRows := k.generateHeaderRows(month, year, currentTime, true, false)
+	keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, headerRows...)
 
 	rowMonthsOne, rowMonthsTwo := k.addMonthsNamesRow(year)
 	keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, rowMonthsOne, rowMonthsTwo)
 
-	return keyboard
+	return k.addExtraRows(keyboard)
 }
 
 // GenerateSelectYears ...
 func (k *KeyboardFormer) GenerateSelectYears(month, year int, currentTime time.Time) models.InlineKeyboardMarkup {
 	var keyboard models.InlineKeyboardMarkup
-	monthYearRow := k.generateMonthYearRow(month, year, currentTime, false, true)
-	keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, monthYearRow)
+	headerRows := k.generateHeaderRows(month, year, currentTime, false, true)
+	keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, headerRows...)
 
 	rowYears := k.addYearsNamesRow(month, year)
 	keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, rowYears)
 
-	return keyboard
+	return k.addExtraRows(keyboard)
 }
 
 // GenerateCalendar ...
 func (k *KeyboardFormer) GenerateCalendar(month, year int, currentTime time.Time) models.InlineKeyboardMarkup {
 	var keyboard models.InlineKeyboardMarkup // unknown len, may 6-8.
-	monthYearRow := k.generateMonthYearRow(month, year, currentTime, false, false)
-	keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, monthYearRow)
+	headerRows := k.generateHeaderRows(month, year, currentTime, false, false)
+	keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, headerRows...)
 
-	rowDays := k.addDaysNamesRow(month, year)
-	keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, rowDays)
+	if !k.hideDaysNames {
+		rowDays := k.addDaysNamesRow(month, year)
+		keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, rowDays)
+	}
 
 	keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, k.GenerateCurrentMonth(month, year, currentTime)...)
 
-	return keyboard
+	if len(k.footerButtons) > 0 {
+		keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, k.generateFooterRow(month, year))
+	}
+
+	return k.addExtraRows(keyboard)
 }
 
 // GenerateDefaultCalendar ...
 func (k *KeyboardFormer) GenerateDefaultCalendar(currentTime time.Time) models.InlineKeyboardMarkup {
-	year := currentTime.Year()
-	month := int(currentTime.Month())
+	month, year := k.defaultMonthYear(currentTime)
 	return k.GenerateCalendar(month, year, currentTime)
 }
 
@@ -185,17 +350,21 @@ func (k *KeyboardFormer) generateMonthYearRow(
 
 	btnPrevMonth, btnNextMonth, btnMonth := k.getMonthsButtons(month, year, needShowSelectedMonth)
 	btnPrevYear, btnNextYear, btnYear := k.getYearsButtons(month, year, needShowSelectedYear)
-	btnBeauty := k.formBtnBeauty(month, year, currentTime)
 
-	row = append(row, btnPrevYear, btnPrevMonth, btnMonth, btnBeauty, btnYear, btnNextMonth, btnNextYear)
-	return row
+	row = append(row, btnPrevYear, btnPrevMonth, btnMonth)
+	if !k.hideHomeButton {
+		row = append(row, k.formBtnBeauty(month, year, currentTime))
+	}
+	row = append(row, btnYear, btnNextMonth, btnNextYear)
+	return k.mirrorRowIfNeeded(row)
 }
 
 func (k *KeyboardFormer) getMonthsButtons(month, year int, needShowSelectedMonth bool) (
 	btnPrevMonth, btnNextMonth, btnMonth models.InlineKeyboardButton,
 ) {
-	btnPrevMonth = models.NewInlineKeyboardButton(prevMonthActionName, k.payloadEncoderDecoder.Encoding(prevMonthAction, 0, month, year))
-	btnNextMonth = models.NewInlineKeyboardButton(nextMonthActionName, k.payloadEncoderDecoder.Encoding(nextMonthAction, 0, month, year))
+	prevMonthName, nextMonthName := k.getDirectionNames(k.prevMonthName, k.nextMonthName)
+	btnPrevMonth = models.NewInlineKeyboardButton(prevMonthName, k.payloadEncoderDecoder.Encoding(prevMonthAction, 0, month, year))
+	btnNextMonth = models.NewInlineKeyboardButton(nextMonthName, k.payloadEncoderDecoder.Encoding(nextMonthAction, 0, month, year))
 
 	// To be able to return to the current month by pressing again.
 	if needShowSelectedMonth {
@@ -210,14 +379,16 @@ func (k *KeyboardFormer) getMonthsButtons(month, year int, needShowSelectedMonth
 func (k *KeyboardFormer) getYearsButtons(month, year int, needShowSelectedYear bool) (
 	btnPrevYear, btnNextYear, btnYear models.InlineKeyboardButton,
 ) {
-	btnPrevYear = models.NewInlineKeyboardButton(prevYearActionName, k.payloadEncoderDecoder.Encoding(prevYearAction, 0, month, year))
-	btnNextYear = models.NewInlineKeyboardButton(nextYearActionName, k.payloadEncoderDecoder.Encoding(nextYearAction, 0, month, year))
+	prevYearName, nextYearName := k.getDirectionNames(k.prevYearName, k.nextYearName)
+	btnPrevYear = models.NewInlineKeyboardButton(prevYearName, k.payloadEncoderDecoder.Encoding(prevYearAction, 0, month, year))
+	btnNextYear = models.NewInlineKeyboardButton(nextYearName, k.payloadEncoderDecoder.Encoding(nextYearAction, 0, month, year))
 
 	// To be able to return to the current year by pressing again.
+	yearName := k.getNumeralSystem().Format(year)
 	if needShowSelectedYear {
-		btnYear = models.NewInlineKeyboardButton(strconv.Itoa(year), k.payloadEncoderDecoder.Encoding(showSelectedAction, 0, month, year))
+		btnYear = models.NewInlineKeyboardButton(yearName, k.payloadEncoderDecoder.Encoding(showSelectedAction, 0, month, year))
 	} else {
-		btnYear = models.NewInlineKeyboardButton(strconv.Itoa(year), k.payloadEncoderDecoder.Encoding(selectYearAction, 0, month, year))
+		btnYear = models.NewInlineKeyboardButton(yearName, k.payloadEncoderDecoder.Encoding(selectYearAction, 0, month, year))
 	}
 
 	return btnPrevYear, btnNextYear, btnYear
@@ -225,8 +396,7 @@ func (k *KeyboardFormer) getYearsButtons(month, year int, needShowSelectedYear b
 
 // For some beauty + return to default.
 func (k *KeyboardFormer) formBtnBeauty(month, year int, currentTime time.Time) models.InlineKeyboardButton {
-	curYear := currentTime.Year()
-	curMonth := int(currentTime.Month())
+	curMonth, curYear := k.defaultMonthYear(currentTime)
 	beautyCallback := getBeautyCallback(curMonth, curYear, month, year)
 
 	return models.NewInlineKeyboardButton(k.homeButtonForBeauty, k.payloadEncoderDecoder.Encoding(beautyCallback, 0, curMonth, curYear))
@@ -246,7 +416,7 @@ func (k *KeyboardFormer) addDaysNamesRow(curMonth, curYear int) (rowDays []model
 		rowDays = append(rowDays, btn)
 	}
 
-	return rowDays
+	return k.mirrorRowIfNeeded(rowDays)
 }
 
 func (k *KeyboardFormer) addMonthsNamesRow(year int) (rowMonthsOne, rowMonthsTwo []models.InlineKeyboardButton) {
@@ -263,29 +433,174 @@ func (k *KeyboardFormer) addMonthsNamesRow(year int) (rowMonthsOne, rowMonthsTwo
 		rowMonthsTwo = append(rowMonthsTwo, btn)
 	}
 
-	return rowMonthsOne, rowMonthsTwo
+	return k.mirrorRowIfNeeded(rowMonthsOne), k.mirrorRowIfNeeded(rowMonthsTwo)
 }
 
 func (k *KeyboardFormer) addYearsNamesRow(month, currentYear int) (rowYears []models.InlineKeyboardButton) {
 	rowYears = make([]models.InlineKeyboardButton, 0, k.sumYearsForChoose+1)
+	numeralSystem := k.getNumeralSystem()
 
 	// Past years.
 	for year := currentYear - k.yearsBackForChoose; year < currentYear; year++ {
-		btn := models.NewInlineKeyboardButton(strconv.Itoa(year), k.payloadEncoderDecoder.Encoding(showSelectedAction, 0, month, year))
+		btn := models.NewInlineKeyboardButton(numeralSystem.Format(year), k.payloadEncoderDecoder.Encoding(showSelectedAction, 0, month, year))
 		rowYears = append(rowYears, btn)
 	}
 
 	// Current year.
-	btnCur := models.NewInlineKeyboardButton(strconv.Itoa(currentYear), k.payloadEncoderDecoder.Encoding(showSelectedAction, 0, month, currentYear))
+	btnCur := models.NewInlineKeyboardButton(numeralSystem.Format(currentYear), k.payloadEncoderDecoder.Encoding(showSelectedAction, 0, month, currentYear))
 	rowYears = append(rowYears, btnCur)
 
 	// Next years.
 	for year := currentYear + 1; year <= currentYear+k.yearsForwardForChoose; year++ {
-		btn := models.NewInlineKeyboardButton(strconv.Itoa(year), k.payloadEncoderDecoder.Encoding(showSelectedAction, 0, month, year))
+		btn := models.NewInlineKeyboardButton(numeralSystem.Format(year), k.payloadEncoderDecoder.Encoding(showSelectedAction, 0, month, year))
 		rowYears = append(rowYears, btn)
 	}
 
-	return rowYears
+	return k.mirrorRowIfNeeded(rowYears)
+}
+
+// Caller's own rows are copied, so the keyboard may be changed without any effect on the settings.
+func (k *KeyboardFormer) addExtraRows(keyboard models.InlineKeyboardMarkup) models.InlineKeyboardMarkup {
+	if len(k.extraRowsAbove) == 0 && len(k.extraRowsBelow) == 0 {
+		return keyboard
+	}
+
+	rows := make([][]models.InlineKeyboardButton, 0, len(k.extraRowsAbove)+len(keyboard.InlineKeyboard)+len(k.extraRowsBelow))
+	rows = append(rows, copyRows(k.extraRowsAbove)...)
+	rows = append(rows, keyboard.InlineKeyboard...)
+	rows = append(rows, copyRows(k.extraRowsBelow)...)
+	keyboard.InlineKeyboard = rows
+
+	return keyboard
+}
+
+// Deep copy for the options: the slices and the day button former are not shared with the source.
+func (k *KeyboardFormer) clone() *KeyboardFormer {
+	kf := *k
+	kf.footerButtons = append([]FooterButton(nil), k.footerButtons...)
+	kf.extraRowsAbove = copyRows(k.extraRowsAbove)
+	kf.extraRowsBelow = copyRows(k.extraRowsBelow)
+	kf.buttonsTextWrapper = k.buttonsTextWrapper.ApplyNewOptions()
+	kf.monthEventsCount = nil
+	return &kf
+}
+
+func copyRows(rows [][]models.InlineKeyboardButton) [][]models.InlineKeyboardButton {
+	if rows == nil {
+		return nil
+	}
+
+	rowsCopy := make([][]models.InlineKeyboardButton, 0, len(rows))
+	for _, row := range rows {
+		rowCopy := append([]models.InlineKeyboardButton(nil), row...)
+		for i := range rowCopy {
+			rowCopy[i] = copyButton(rowCopy[i])
+		}
+		rowsCopy = append(rowsCopy, rowCopy)
+	}
+
+	return rowsCopy
+}
+
+// The pointer fields are copied as well, so the copy shares nothing with the button.
+func copyButton(button models.InlineKeyboardButton) models.InlineKeyboardButton {
+	button.WebApp = copyPointer(button.WebApp)
+	button.LoginURL = copyPointer(button.LoginURL)
+	button.SwitchInlineQuery = copyPointer(button.SwitchInlineQuery)
+	button.SwitchInlineQueryCurrentChat = copyPointer(button.SwitchInlineQueryCurrentChat)
+	button.SwitchInlineQueryChosenChat = copyPointer(button.SwitchInlineQueryChosenChat)
+	button.CopyText = copyPointer(button.CopyText)
+	button.CallbackGame = copyPointer(button.CallbackGame)
+
+	return button
+}
+
+func copyPointer[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	value := *p
+	return &value
+}
+
+// Day button text with the user location, if there is one.
+func (k *KeyboardFormer) dayButtonText(day, month, year int, currentTime time.Time) (string, bool) {
+	return k.dayButtonTextWithParams(day, month, year, currentTime, k.dayButtonParams())
+}
+
+// The availability lookup of the day, traced if the render is.
+func (k *KeyboardFormer) dayButtonTextWithParams(
+	day, month, year int,
+	currentTime time.Time,
+	params day_button_former.DayButtonParams,
+) (string, bool) {
+	if !k.traced {
+		text, isUnselectableDay, err := k.lookupDayButtonText(day, month, year, currentTime, params)
+		k.renderErrs.add(err)
+		return text, isUnselectableDay
+	}
+
+	_, span := tracing.Start(k.renderCtx, SpanAvailability)
+	defer span.End()
+	span.SetAttribute(AttributeDate, time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC).Format(time.DateOnly))
+	text, isUnselectableDay, err := k.lookupDayButtonText(day, month, year, currentTime, params)
+	if err != nil {
+		span.RecordError(err)
+		k.renderErrs.add(err)
+	}
+	span.SetAttribute(AttributeUnselectable, isUnselectableDay)
+	return text, isUnselectableDay
+}
+
+// The days buttons former call with the render context and its error, see day_button_former.ContextDaysButtonsText.
+func (k *KeyboardFormer) lookupDayButtonText(
+	day, month, year int,
+	currentTime time.Time,
+	params day_button_former.DayButtonParams,
+) (string, bool, error) {
+	if cbt, ok := k.buttonsTextWrapper.(day_button_former.ContextDaysButtonsText); ok && k.renderCtx != nil {
+		return cbt.DayButtonTextWrapperContext(k.renderCtx, day, month, year, currentTime, params)
+	}
+	text, isUnselectableDay := day_button_former.DayButtonTextWithParams(k.buttonsTextWrapper, day, month, year, currentTime,
+		params)
+	return text, isUnselectableDay, nil
+}
+
+// Render settings for the days buttons.
+func (k *KeyboardFormer) dayButtonParams() day_button_former.DayButtonParams {
+	return day_button_former.DayButtonParams{
+		UserLocation:    k.userLocation,
+		PreselectedDate: k.preselectedDate,
+		Context:         k.renderCtx,
+	}
+}
+
+// The month of the default calendar: the preselected date month or the current one.
+func (k *KeyboardFormer) defaultMonthYear(currentTime time.Time) (month, year int) {
+	if !k.preselectedDate.IsZero() {
+		return int(k.preselectedDate.Month()), k.preselectedDate.Year()
+	}
+	return int(currentTime.Month()), currentTime.Year()
+}
+
+// In right-to-left mode the row is mirrored, so the arrows pointing to the past are on the right.
+// Swapping the names keeps them pointing outwards, while their actions are not changed.
+func (k *KeyboardFormer) getDirectionNames(prevName, nextName string) (string, string) {
+	if k.rightToLeft {
+		return nextName, prevName
+	}
+	return prevName, nextName
+}
+
+// Reverses the row in place for right-to-left mode.
+func (k *KeyboardFormer) mirrorRowIfNeeded(row []models.InlineKeyboardButton) []models.InlineKeyboardButton {
+	if !k.rightToLeft {
+		return row
+	}
+	for i, j := 0, len(row)-1; i < j; i, j = i+1, j-1 {
+		row[i], row[j] = row[j], row[i]
+	}
+	return row
 }
 
 // GetUnselectableDays ...
@@ -304,16 +619,42 @@ func (k *KeyboardFormer) GetCurrentConfig() FlatConfig {
 		MonthNames:                 k.monthNames,
 		HomeButtonForBeauty:        k.homeButtonForBeauty,
 		PayloadEncoderDecoder:      k.payloadEncoderDecoder,
+		RightToLeft:                k.rightToLeft,
+		NumeralSystem:              dayButtonFormerConfig.NumeralSystem,
+		FooterButtons:              append([]FooterButton(nil), k.footerButtons...),
+		ExtraRowsAbove:             copyRows(k.extraRowsAbove),
+		ExtraRowsBelow:             copyRows(k.extraRowsBelow),
+		HeaderLayout:               k.headerLayout,
+		HideHomeButton:             k.hideHomeButton,
+		PrevMonthName:              k.prevMonthName,
+		NextMonthName:              k.nextMonthName,
+		PrevYearName:               k.prevYearName,
+		NextYearName:               k.nextYearName,
+		AdjacentDaysMode:           k.adjacentDaysMode,
+		EventSource:                k.eventSource,
+		EventMarker:                k.eventMarker,
+		ShowEventsCount:            k.showEventsCount,
+		FixedWeeksRows:             k.fixedWeeksRows,
+		HideDaysNames:              k.hideDaysNames,
 		PrefixForCurrentDay:        dayButtonFormerConfig.PrefixForCurrentDay,
 		PostfixForCurrentDay:       dayButtonFormerConfig.PostfixForCurrentDay,
 		PrefixForNonSelectedDay:    dayButtonFormerConfig.PrefixForNonSelectedDay,
 		PostfixForNonSelectedDay:   dayButtonFormerConfig.PostfixForNonSelectedDay,
 		PrefixForPickDay:           dayButtonFormerConfig.PrefixForPickDay,
 		PostfixForPickDay:          dayButtonFormerConfig.PostfixForPickDay,
+		PrefixForAdjacentDay:       dayButtonFormerConfig.PrefixForAdjacentDay,
+		PostfixForAdjacentDay:      dayButtonFormerConfig.PostfixForAdjacentDay,
+		PrefixForPreselectedDay:    dayButtonFormerConfig.PrefixForPreselectedDay,
+		PostfixForPreselectedDay:   dayButtonFormerConfig.PostfixForPreselectedDay,
 		UnselectableDaysBeforeTime: dayButtonFormerConfig.UnselectableDaysBeforeTime,
 		UnselectableDaysAfterTime:  dayButtonFormerConfig.UnselectableDaysAfterTime,
 		UnselectableDays:           dayButtonFormerConfig.UnselectableDays,
 		Timezone:                   dayButtonFormerConfig.Timezone,
+		MinimumLeadTime:            dayButtonFormerConfig.MinimumLeadTime,
+		DayDecorators:              dayButtonFormerConfig.DayDecorators,
+		LoadProvider:               dayButtonFormerConfig.LoadProvider,
+		LoadLevels:                 dayButtonFormerConfig.LoadLevels,
+		FullLoadThreshold:          dayButtonFormerConfig.FullLoadThreshold,
 	}
 }
 
@@ -321,3 +662,13 @@ func (k *KeyboardFormer) GetCurrentConfig() FlatConfig {
 func (k *KeyboardFormer) GetTimezone() time.Location {
 	return k.buttonsTextWrapper.GetTimezone()
 }
+
+// The numeral system of the days labels, the years labels and the events counts use the same digits.
+func (k *KeyboardFormer) getNumeralSystem() day_button_former.NumeralSystem {
+	if former, ok := k.buttonsTextWrapper.(interface {
+		GetNumeralSystem() day_button_former.NumeralSystem
+	}); ok {
+		return former.GetNumeralSystem()
+	}
+	return k.buttonsTextWrapper.GetCurrentConfig().NumeralSystem
+}
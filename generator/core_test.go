@@ -2095,3 +2095,271 @@ func TestGetCurrentConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerateCalendarRightToLeft(t *testing.T) {
+	t.Parallel()
+
+	currentTime := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	ltrKeyboard := NewKeyboardFormer().GenerateCalendarKeyboard("", currentTime).InlineKeyboardMarkup.InlineKeyboard
+	rtlKeyboard := NewKeyboardFormer(ChangeRightToLeft(true)).GenerateCalendarKeyboard("", currentTime).InlineKeyboardMarkup.InlineKeyboard
+
+	wantHeader := []models.InlineKeyboardButton{
+		{Text: "«", CallbackData: "calendar/ney_00.06.2023"},
+		{Text: "<", CallbackData: "calendar/nem_00.06.2023"},
+		{Text: "2023", CallbackData: "calendar/sey_00.06.2023"},
+		{Text: "🏩", CallbackData: "calendar/sdn_00.06.2023"},
+		{Text: "Jun", CallbackData: "calendar/sem_00.06.2023"},
+		{Text: ">", CallbackData: "calendar/prm_00.06.2023"},
+		{Text: "»", CallbackData: "calendar/pry_00.06.2023"},
+	}
+	if !isSlicesEqual(rtlKeyboard[0], wantHeader) {
+		t.Errorf("unexpected right-to-left header: got: %v, want: %v", rtlKeyboard[0], wantHeader)
+	}
+
+	if len(ltrKeyboard) != len(rtlKeyboard) {
+		t.Errorf("unexpected right-to-left rows count: got: %v, want: %v", len(rtlKeyboard), len(ltrKeyboard))
+		return
+	}
+
+	// Days names and weeks rows are mirrored without any other changes.
+	for i := 1; i < len(ltrKeyboard); i++ {
+		for j := range ltrKeyboard[i] {
+			mirroredButton := rtlKeyboard[i][len(rtlKeyboard[i])-1-j]
+			if ltrKeyboard[i][j] != mirroredButton {
+				t.Errorf("row %v button %v is not mirrored: got: %v, want: %v", i, j, mirroredButton, ltrKeyboard[i][j])
+			}
+		}
+	}
+}
+
+func TestGenerateSelectMonthsAndYearsRightToLeft(t *testing.T) {
+	t.Parallel()
+
+	k := newDefaultKeyboardFormer()
+	k.rightToLeft = true
+	currentTime := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	months := k.GenerateSelectMonths(6, 2023, currentTime).InlineKeyboard
+	if months[1][0].Text != "Jun" || months[1][5].Text != "Jan" {
+		t.Errorf("unexpected right-to-left months row: %v", months[1])
+	}
+	if months[2][0].Text != "Dec" || months[2][5].Text != "Jul" {
+		t.Errorf("unexpected right-to-left months row: %v", months[2])
+	}
+
+	years := k.GenerateSelectYears(6, 2023, currentTime).InlineKeyboard
+	lastYear := years[1][len(years[1])-1]
+	if years[1][0].Text != "2026" || lastYear.Text != "2023" {
+		t.Errorf("unexpected right-to-left years row: %v", years[1])
+	}
+}
+
+func TestGenerateCalendarWithExtraRows(t *testing.T) {
+	t.Parallel()
+
+	emptyQuery := ""
+	rowsAbove := [][]models.InlineKeyboardButton{
+		{{Text: "Site", URL: "https://example.com"}},
+	}
+	rowsBelow := [][]models.InlineKeyboardButton{
+		{{Text: "No date", CallbackData: "my/no_date"}, {Text: "Back to menu", CallbackData: "my/menu"}},
+		{{Text: "App", WebApp: &models.WebAppInfo{URL: "https://example.com/app"}}, {Text: "Share", SwitchInlineQuery: &emptyQuery}},
+	}
+
+	kf := NewKeyboardFormer(
+		ChangeExtraRowsAbove(rowsAbove),
+		ChangeExtraRowsBelow(rowsBelow),
+		ChangeFooterButtons(FooterButton{Action: FooterActionCancel}),
+	)
+	// Changes of the caller's rows after the option do not change the settings.
+	rowsBelow[0][0].Text = "changed"
+
+	currentTime := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		callbackPayload string
+		wantFooter      bool
+	}{
+		{name: "default calendar", callbackPayload: ""},
+		{name: "next month", callbackPayload: "calendar/nem_00.06.2023"},
+		{name: "select month", callbackPayload: "calendar/sem_00.06.2023"},
+		{name: "select year", callbackPayload: "calendar/sey_00.06.2023"},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			keyboard := kf.GenerateCalendarKeyboard(tt.callbackPayload, currentTime).InlineKeyboardMarkup.InlineKeyboard
+			if !isSlicesEqual(keyboard[0], rowsAbove[0]) {
+				t.Errorf("unexpected first row: got: %v, want: %v", keyboard[0], rowsAbove[0])
+			}
+			if keyboard[1][0].Text != "«" {
+				t.Errorf("header is not the second row: %v", keyboard[1])
+			}
+			lastRows := keyboard[len(keyboard)-2:]
+			if lastRows[0][0].Text != "No date" || lastRows[0][1].CallbackData != "my/menu" {
+				t.Errorf("unexpected penultimate row: %v", lastRows[0])
+			}
+			if lastRows[1][0].WebApp == nil || lastRows[1][1].SwitchInlineQuery == nil {
+				t.Errorf("unexpected last row: %v", lastRows[1])
+			}
+		},
+		)
+	}
+
+	// Footer stays between the calendar and the rows below.
+	keyboard := kf.GenerateCalendarKeyboard("", currentTime).InlineKeyboardMarkup.InlineKeyboard
+	if keyboard[len(keyboard)-3][0].CallbackData != "calendar/cnl_00.06.2023" {
+		t.Errorf("unexpected footer position: %v", keyboard[len(keyboard)-3])
+	}
+
+	// The keyboard may be changed without any effect on the settings.
+	keyboard[0][0].Text = "changed"
+	if kf.GetCurrentConfig().ExtraRowsAbove[0][0].Text != "Site" {
+		t.Errorf("extra rows changed through the keyboard: %v", kf.GetCurrentConfig().ExtraRowsAbove)
+	}
+}
+
+func TestExtraRowsButtonsAreDeepCopied(t *testing.T) {
+	t.Parallel()
+
+	kf := NewKeyboardFormer(ChangeExtraRowsAbove([][]models.InlineKeyboardButton{
+		{{Text: "App", WebApp: &models.WebAppInfo{URL: "https://example.com/app"}}},
+	}))
+	currentTime := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	config := kf.GetCurrentConfig()
+	config.ExtraRowsAbove[0][0].WebApp.URL = "https://example.com/changed"
+
+	keyboard := kf.GenerateCalendarKeyboard("", currentTime).InlineKeyboardMarkup.InlineKeyboard
+	if keyboard[0][0].WebApp.URL != "https://example.com/app" {
+		t.Errorf("extra rows changed through the config: %v", keyboard[0][0].WebApp)
+	}
+
+	// The keyboard may be changed without any effect on the next renders.
+	keyboard[0][0].WebApp.URL = "https://example.com/changed"
+	keyboard = kf.GenerateCalendarKeyboard("", currentTime).InlineKeyboardMarkup.InlineKeyboard
+	if keyboard[0][0].WebApp.URL != "https://example.com/app" {
+		t.Errorf("extra rows changed through the keyboard: %v", keyboard[0][0].WebApp)
+	}
+}
+
+func TestGeneratedKeyboardsAreValid(t *testing.T) {
+	t.Parallel()
+	k := newDefaultKeyboardFormer()
+	k.footerButtons = []FooterButton{{Action: FooterActionToday}, {Action: FooterActionClear}}
+	currentTime := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		keyboard models.InlineKeyboardMarkup
+	}{
+		{name: "calendar", keyboard: k.GenerateCalendar(6, 2023, currentTime)},
+		{name: "select months", keyboard: k.GenerateSelectMonths(6, 2023, currentTime)},
+		{name: "select years", keyboard: k.GenerateSelectYears(6, 2023, currentTime)},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.keyboard.Validate(); err != nil {
+				t.Errorf("generated keyboard is not valid: %v", err)
+			}
+		},
+		)
+	}
+}
+
+func TestGenerateCalendarWithFixedWeeksRows(t *testing.T) {
+	t.Parallel()
+
+	currentTime := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	type args struct {
+		month, year int
+		options     []func(KeyboardGenerator) KeyboardGenerator
+	}
+
+	tests := []struct {
+		name         string
+		args         args
+		wantRows     int
+		wantLastWeek []models.InlineKeyboardButton
+	}{
+		{
+			name:     "four weeks month is not changed by default",
+			args:     args{month: 2, year: 2021},
+			wantRows: 6,
+		},
+		{
+			name:     "four weeks month with blank rows",
+			args:     args{month: 2, year: 2021, options: []func(KeyboardGenerator) KeyboardGenerator{ChangeFixedWeeksRows(true)}},
+			wantRows: 8,
+			wantLastWeek: []models.InlineKeyboardButton{
+				{Text: " ", CallbackData: "calendar/sdn_00.02.2021"},
+				{Text: " ", CallbackData: "calendar/sdn_00.02.2021"},
+				{Text: " ", CallbackData: "calendar/sdn_00.02.2021"},
+				{Text: " ", CallbackData: "calendar/sdn_00.02.2021"},
+				{Text: " ", CallbackData: "calendar/sdn_00.02.2021"},
+				{Text: " ", CallbackData: "calendar/sdn_00.02.2021"},
+				{Text: " ", CallbackData: "calendar/sdn_00.02.2021"},
+			},
+		},
+		{
+			name: "four weeks month with adjacent days",
+			args: args{month: 2, year: 2021, options: []func(KeyboardGenerator) KeyboardGenerator{
+				ChangeFixedWeeksRows(true), ChangeAdjacentDaysMode(AdjacentDaysSelect),
+				ApplyNewOptionsForButtonsTextWrapper(
+					day_button_former.ChangeUnselectableDaysBeforeDate(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)),
+				),
+			}},
+			wantRows: 8,
+			wantLastWeek: []models.InlineKeyboardButton{
+				{Text: "·8", CallbackData: "calendar/sed_08.03.2021"},
+				{Text: "·9", CallbackData: "calendar/sed_09.03.2021"},
+				{Text: "·10", CallbackData: "calendar/sed_10.03.2021"},
+				{Text: "·11", CallbackData: "calendar/sed_11.03.2021"},
+				{Text: "·12", CallbackData: "calendar/sed_12.03.2021"},
+				{Text: "·13", CallbackData: "calendar/sed_13.03.2021"},
+				{Text: "·14", CallbackData: "calendar/sed_14.03.2021"},
+			},
+		},
+		{
+			name:     "five weeks month",
+			args:     args{month: 6, year: 2023, options: []func(KeyboardGenerator) KeyboardGenerator{ChangeFixedWeeksRows(true)}},
+			wantRows: 8,
+		},
+		{
+			name:     "six weeks month",
+			args:     args{month: 7, year: 2023, options: []func(KeyboardGenerator) KeyboardGenerator{ChangeFixedWeeksRows(true)}},
+			wantRows: 8,
+		},
+		{
+			name: "without days names",
+			args: args{month: 6, year: 2023, options: []func(KeyboardGenerator) KeyboardGenerator{
+				ChangeFixedWeeksRows(true), ChangeHideDaysNames(true),
+			}},
+			wantRows: 7,
+		},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			kf, _ := newDefaultKeyboardFormer().ApplyNewOptions(tt.args.options...).(*KeyboardFormer)
+			keyboard := kf.GenerateCalendar(tt.args.month, tt.args.year, currentTime).InlineKeyboard
+			if len(keyboard) != tt.wantRows {
+				t.Errorf("unexpected rows count: got: %v, want: %v", len(keyboard), tt.wantRows)
+				return
+			}
+			if tt.wantLastWeek != nil && !isSlicesEqual(keyboard[len(keyboard)-1], tt.wantLastWeek) {
+				t.Errorf("unexpected last week: got: %v, want: %v", keyboard[len(keyboard)-1], tt.wantLastWeek)
+			}
+		},
+		)
+	}
+}
@@ -0,0 +1,178 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/thevan4/telegram-calendar/day_button_former"
+	"github.com/thevan4/telegram-calendar/models"
+	"github.com/thevan4/telegram-calendar/tracing"
+)
+
+// EventSource returns events for the calendar month.
+// The events are matched with the days by their start date at the location.
+type EventSource interface {
+	EventsForMonth(year int, month time.Month, location *time.Location) ([]models.Event, error)
+}
+
+// ContextEventSource EventSource that takes the render context, a database lookup for example.
+// The lookup is not made once the context is done.
+type ContextEventSource interface {
+	EventSource
+	EventsForMonthContext(ctx context.Context, year int, month time.Month, location *time.Location) ([]models.Event, error)
+}
+
+// EventSourceContextFunc adapter to use a function as ContextEventSource.
+type EventSourceContextFunc func(ctx context.Context, year int, month time.Month, location *time.Location) ([]models.Event, error)
+
+// EventsForMonth calls f(context.Background(), year, month, location).
+func (f EventSourceContextFunc) EventsForMonth(year int, month time.Month, location *time.Location) ([]models.Event, error) {
+	return f(context.Background(), year, month, location)
+}
+
+// EventsForMonthContext calls f(ctx, year, month, location).
+func (f EventSourceContextFunc) EventsForMonthContext(
+	ctx context.Context,
+	year int,
+	month time.Month,
+	location *time.Location,
+) ([]models.Event, error) {
+	return f(ctx, year, month, location)
+}
+
+// MemoryEventSource in-memory EventSource, mostly for tests and small bots.
+type MemoryEventSource struct {
+	sync.RWMutex
+	events []models.Event
+}
+
+// NewMemoryEventSource maker for MemoryEventSource.
+func NewMemoryEventSource(events ...models.Event) *MemoryEventSource {
+	return &MemoryEventSource{events: append([]models.Event(nil), events...)}
+}
+
+// Add adds the events.
+func (mes *MemoryEventSource) Add(events ...models.Event) {
+	mes.Lock()
+	defer mes.Unlock()
+	mes.events = append(mes.events, events...)
+}
+
+// EventsForMonth events starting at the month, sorted by start.
+func (mes *MemoryEventSource) EventsForMonth(year int, month time.Month, location *time.Location) ([]models.Event, error) {
+	mes.RLock()
+	defer mes.RUnlock()
+
+	monthEvents := make([]models.Event, 0)
+	for _, event := range mes.events {
+		start := event.Start.In(location)
+		if start.Year() == year && start.Month() == month {
+			monthEvents = append(monthEvents, event)
+		}
+	}
+	sort.SliceStable(monthEvents, func(i, j int) bool {
+		return monthEvents[i].Start.Before(monthEvents[j].Start)
+	})
+
+	return monthEvents, nil
+}
+
+// Returns a copy of KeyboardFormer with the number of events per day of the month,
+// or the KeyboardFormer itself if there is no event source.
+func (k *KeyboardFormer) withMonthEvents(month, year int) *KeyboardFormer {
+	if k.eventSource == nil {
+		return k
+	}
+
+	location := k.GetTimezone()
+	events, err := k.eventsForMonth(year, month, &location)
+	if err != nil {
+		k.renderErrs.add(err)
+		return k // the calendar is shown without markers, the error is returned by the render with the context.
+	}
+
+	kf := *k
+	kf.monthEventsCount = make(map[int]int, len(events))
+	for _, event := range events {
+		start := event.Start.In(&location)
+		if start.Year() == year && int(start.Month()) == month {
+			kf.monthEventsCount[start.Day()]++
+		}
+	}
+
+	return &kf
+}
+
+// Events of the tapped day, nil if there are none or the event source failed.
+func (k *KeyboardFormer) dayEvents(day, month, year int) []models.Event {
+	if k.eventSource == nil {
+		return nil
+	}
+
+	location := k.GetTimezone()
+	events, err := k.eventsForMonth(year, month, &location)
+	if err != nil {
+		k.renderErrs.add(err)
+		return nil
+	}
+
+	var dayEvents []models.Event
+	for _, event := range events {
+		start := event.Start.In(&location)
+		if start.Year() == year && int(start.Month()) == month && start.Day() == day {
+			dayEvents = append(dayEvents, event)
+		}
+	}
+
+	return dayEvents
+}
+
+// The event source lookup with the render context, traced if the render is.
+func (k *KeyboardFormer) eventsForMonth(year, month int, location *time.Location) ([]models.Event, error) {
+	if !k.traced {
+		return k.lookupEventsForMonth(year, month, location)
+	}
+
+	_, span := tracing.Start(k.renderCtx, SpanEvents)
+	defer span.End()
+	span.SetAttribute(AttributeMonth, fmt.Sprintf("%04d-%02d", year, month))
+	events, err := k.lookupEventsForMonth(year, month, location)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	span.SetAttribute(AttributeEventsCount, len(events))
+	return events, nil
+}
+
+func (k *KeyboardFormer) lookupEventsForMonth(year, month int, location *time.Location) ([]models.Event, error) {
+	if k.renderCtx == nil {
+		return k.eventSource.EventsForMonth(year, time.Month(month), location)
+	}
+	if err := k.renderCtx.Err(); err != nil {
+		return nil, err
+	}
+	if source, ok := k.eventSource.(ContextEventSource); ok {
+		return source.EventsForMonthContext(k.renderCtx, year, time.Month(month), location)
+	}
+	return k.eventSource.EventsForMonth(year, time.Month(month), location)
+}
+
+func (k *KeyboardFormer) selectEventsDay(day, month, year int) models.GenerateCalendarKeyboardResponse {
+	location := k.GetTimezone()
+	return models.GenerateCalendarKeyboardResponse{
+		EventsDay: day_button_former.FormDateTime(day, month, year, &location),
+		Events:    k.dayEvents(day, month, year),
+	}
+}
+
+// "•" or "•3", depends on showEventsCount.
+func (k *KeyboardFormer) eventsMarker(eventsCount int) string {
+	if !k.showEventsCount {
+		return k.eventMarker
+	}
+	return k.eventMarker + k.getNumeralSystem().Format(eventsCount)
+}
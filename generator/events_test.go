@@ -0,0 +1,146 @@
+package generator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/thevan4/telegram-calendar/day_button_former"
+	"github.com/thevan4/telegram-calendar/models"
+)
+
+type failingEventSource struct{}
+
+func (failingEventSource) EventsForMonth(_ int, _ time.Month, _ *time.Location) ([]models.Event, error) {
+	return nil, errors.New("source is unavailable")
+}
+
+func TestGenerateCalendarWithEvents(t *testing.T) {
+	t.Parallel()
+
+	currentTime := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	standup := models.Event{ID: "1", Title: "Standup", Start: time.Date(2023, 6, 5, 9, 0, 0, 0, time.UTC)}
+	review := models.Event{ID: "2", Title: "Review", Start: time.Date(2023, 6, 5, 15, 0, 0, 0, time.UTC)}
+	retro := models.Event{ID: "3", Title: "Retro", Start: time.Date(2023, 6, 30, 23, 30, 0, 0, time.UTC)}
+	source := NewMemoryEventSource(review, standup, retro)
+
+	tests := []struct {
+		name    string
+		options []func(KeyboardGenerator) KeyboardGenerator
+		// June 5 and June 30 buttons.
+		want []models.InlineKeyboardButton
+	}{
+		{
+			name: "without event source",
+			want: []models.InlineKeyboardButton{
+				{Text: "5", CallbackData: "calendar/sed_05.06.2023"},
+				{Text: "30", CallbackData: "calendar/sed_30.06.2023"},
+			},
+		},
+		{
+			name:    "default marker",
+			options: []func(KeyboardGenerator) KeyboardGenerator{ChangeEventSource(source)},
+			want: []models.InlineKeyboardButton{
+				{Text: "5•", CallbackData: "calendar/evd_05.06.2023"},
+				{Text: "30•", CallbackData: "calendar/evd_30.06.2023"},
+			},
+		},
+		{
+			name: "custom marker with count",
+			options: []func(KeyboardGenerator) KeyboardGenerator{
+				ChangeEventSource(source), ChangeEventMarker("·"), ChangeShowEventsCount(true),
+			},
+			want: []models.InlineKeyboardButton{
+				{Text: "5·2", CallbackData: "calendar/evd_05.06.2023"},
+				{Text: "30·1", CallbackData: "calendar/evd_30.06.2023"},
+			},
+		},
+		{
+			// The marker is the outermost built-in decoration, the custom decorators wrap it.
+			name: "unselectable day and decorator",
+			options: []func(KeyboardGenerator) KeyboardGenerator{
+				ChangeEventSource(source),
+				ApplyNewOptionsForButtonsTextWrapper(
+					day_button_former.ChangeUnselectableDays(map[time.Time]struct{}{time.Date(2023, 6, 5, 0, 0, 0, 0, time.UTC): {}}),
+					day_button_former.ChangeDayDecorators(day_button_former.DayDecoratorFunc(
+						func(_ day_button_former.DayContext) day_button_former.DayDecoration {
+							return day_button_former.DayDecoration{Prefix: "[", Postfix: "]"}
+						})),
+				),
+			},
+			want: []models.InlineKeyboardButton{
+				{Text: "[5❌•]", CallbackData: "calendar/uds_05.06.2023"},
+				{Text: "[30•]", CallbackData: "calendar/evd_30.06.2023"},
+			},
+		},
+		{
+			name:    "failing source",
+			options: []func(KeyboardGenerator) KeyboardGenerator{ChangeEventSource(failingEventSource{})},
+			want: []models.InlineKeyboardButton{
+				{Text: "5", CallbackData: "calendar/sed_05.06.2023"},
+				{Text: "30", CallbackData: "calendar/sed_30.06.2023"},
+			},
+		},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			kf, _ := newDefaultKeyboardFormer().ApplyNewOptions(tt.options...).(*KeyboardFormer)
+			weeks := kf.GenerateCurrentMonth(6, 2023, currentTime)
+			// June 2023 starts on Thursday.
+			got := []models.InlineKeyboardButton{weeks[1][0], weeks[len(weeks)-1][4]}
+			if !isSlicesEqual(got, tt.want) {
+				t.Errorf("unexpected buttons: got: %v, want: %v", got, tt.want)
+			}
+		},
+		)
+	}
+}
+
+func TestGenerateCalendarKeyboardEventsDay(t *testing.T) {
+	t.Parallel()
+
+	currentTime := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	standup := models.Event{ID: "1", Title: "Standup", Start: time.Date(2023, 6, 5, 9, 0, 0, 0, time.UTC)}
+	review := models.Event{ID: "2", Title: "Review", Start: time.Date(2023, 6, 5, 15, 0, 0, 0, time.UTC)}
+	other := models.Event{ID: "3", Title: "Other", Start: time.Date(2023, 6, 6, 9, 0, 0, 0, time.UTC)}
+
+	kf := NewKeyboardFormer(ChangeEventSource(NewMemoryEventSource(review, other, standup)))
+	response := kf.GenerateCalendarKeyboard("calendar/evd_05.06.2023", currentTime)
+
+	if !response.SelectedDay.IsZero() {
+		t.Errorf("events day must not be selected: %v", response.SelectedDay)
+	}
+	if wantDay := time.Date(2023, 6, 5, 0, 0, 0, 0, time.UTC); !response.EventsDay.Equal(wantDay) {
+		t.Errorf("unexpected events day: got: %v, want: %v", response.EventsDay, wantDay)
+	}
+	if wantEvents := []models.Event{standup, review}; !reflect.DeepEqual(response.Events, wantEvents) {
+		t.Errorf("unexpected events: got: %v, want: %v", response.Events, wantEvents)
+	}
+}
+
+func TestMemoryEventSourceUsesLocation(t *testing.T) {
+	t.Parallel()
+
+	tzAsiaT, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Errorf("at time.LoadLocation for Asia/Tokyo error: %v", err)
+		return
+	}
+
+	// July 1 at Tokyo.
+	event := models.Event{ID: "1", Start: time.Date(2023, 6, 30, 20, 0, 0, 0, time.UTC)}
+	source := NewMemoryEventSource()
+	source.Add(event)
+
+	juneUTC, _ := source.EventsForMonth(2023, time.June, time.UTC)
+	juneTokyo, _ := source.EventsForMonth(2023, time.June, tzAsiaT)
+	julyTokyo, _ := source.EventsForMonth(2023, time.July, tzAsiaT)
+	if len(juneUTC) != 1 || len(juneTokyo) != 0 || len(julyTokyo) != 1 {
+		t.Errorf("unexpected events count: june utc %v, june tokyo %v, july tokyo %v",
+			len(juneUTC), len(juneTokyo), len(julyTokyo))
+	}
+}
@@ -0,0 +1,90 @@
+package generator
+
+import (
+	"time"
+
+	"github.com/thevan4/telegram-calendar/day_button_former"
+	"github.com/thevan4/telegram-calendar/models"
+)
+
+// FooterAction is the action of a footer row button.
+type FooterAction int
+
+// Footer row actions.
+const (
+	// FooterActionToday selects the current day of the user.
+	FooterActionToday FooterAction = iota + 1
+	// FooterActionClear clears the selection and keeps the calendar open.
+	FooterActionClear
+	// FooterActionCancel closes the calendar without selection.
+	FooterActionCancel
+	// FooterActionConfirm confirms the selection.
+	FooterActionConfirm
+)
+
+// FooterButton is a button of the footer row under the calendar. Empty text means the default label.
+type FooterButton struct {
+	Action FooterAction
+	Text   string
+}
+
+func (fa FooterAction) payloadAction() string {
+	switch fa {
+	case FooterActionToday:
+		return todayAction
+	case FooterActionClear:
+		return clearAction
+	case FooterActionCancel:
+		return cancelAction
+	case FooterActionConfirm:
+		return confirmAction
+	default:
+		return silentDoNothingAction
+	}
+}
+
+func (fa FooterAction) defaultText() string {
+	switch fa {
+	case FooterActionToday:
+		return todayActionNameDefault
+	case FooterActionClear:
+		return clearActionNameDefault
+	case FooterActionCancel:
+		return cancelActionNameDefault
+	case FooterActionConfirm:
+		return confirmActionNameDefault
+	default:
+		return emptyText
+	}
+}
+
+func (k *KeyboardFormer) generateFooterRow(month, year int) []models.InlineKeyboardButton {
+	row := make([]models.InlineKeyboardButton, 0, len(k.footerButtons))
+	for _, footerButton := range k.footerButtons {
+		text := footerButton.Text
+		if text == "" {
+			text = footerButton.Action.defaultText()
+		}
+		btn := models.NewInlineKeyboardButton(text, k.payloadEncoderDecoder.Encoding(footerButton.Action.payloadAction(), 0, month, year))
+		row = append(row, btn)
+	}
+
+	return k.mirrorRowIfNeeded(row)
+}
+
+// The current day of the user is selected, it is checked by the usual unselectable days rules.
+// The day is taken at the user location (or at the generator timezone), as the current day is marked.
+func (k *KeyboardFormer) selectToday(currentTime time.Time, timeZone *time.Location) models.GenerateCalendarKeyboardResponse {
+	todayLocation := k.GetTimezone()
+	if k.userLocation != nil {
+		todayLocation = *k.userLocation
+	}
+	today := currentTime.In(&todayLocation)
+	day, month, year := today.Day(), int(today.Month()), today.Year()
+	_, isUnselectableDay := k.dayButtonText(day, month, year, currentTime)
+
+	return models.GenerateCalendarKeyboardResponse{
+		SelectedDay:       day_button_former.FormDateTime(day, month, year, timeZone),
+		IsUnselectableDay: isUnselectableDay,
+	}
+}
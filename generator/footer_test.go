@@ -0,0 +1,199 @@
+package generator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thevan4/telegram-calendar/day_button_former"
+	"github.com/thevan4/telegram-calendar/models"
+)
+
+func TestGenerateCalendarWithFooter(t *testing.T) {
+	t.Parallel()
+
+	currentTime := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	kf := NewKeyboardFormer(
+		ChangeFooterButtons(
+			FooterButton{Action: FooterActionToday},
+			FooterButton{Action: FooterActionClear, Text: "🧹"},
+			FooterButton{Action: FooterActionCancel},
+			FooterButton{Action: FooterActionConfirm, Text: "OK"},
+		),
+	)
+
+	wantFooter := []models.InlineKeyboardButton{
+		{Text: "Today", CallbackData: "calendar/tdy_00.07.2023"},
+		{Text: "🧹", CallbackData: "calendar/clr_00.07.2023"},
+		{Text: "Cancel", CallbackData: "calendar/cnl_00.07.2023"},
+		{Text: "OK", CallbackData: "calendar/cfm_00.07.2023"},
+	}
+
+	keyboard := kf.GenerateCalendarKeyboard("calendar/nem_00.06.2023", currentTime).InlineKeyboardMarkup.InlineKeyboard
+	if !isSlicesEqual(keyboard[len(keyboard)-1], wantFooter) {
+		t.Errorf("unexpected footer: got: %v, want: %v", keyboard[len(keyboard)-1], wantFooter)
+	}
+
+	// No footer at months and years selection.
+	months := kf.GenerateCalendarKeyboard("calendar/sem_00.06.2023", currentTime).InlineKeyboardMarkup.InlineKeyboard
+	if len(months) != 3 {
+		t.Errorf("unexpected rows count at months selection: got: %v, want: %v", len(months), 3)
+	}
+
+	rtlKeyboard := GenerateCalendarKeyboardWithOptions(kf, "calendar/nem_00.06.2023", currentTime,
+		WithRightToLeft(true)).InlineKeyboardMarkup.InlineKeyboard
+	if rtlKeyboard[len(rtlKeyboard)-1][0] != wantFooter[3] {
+		t.Errorf("footer is not mirrored at right-to-left mode: %v", rtlKeyboard[len(rtlKeyboard)-1])
+	}
+}
+
+func TestGenerateCalendarWithoutFooter(t *testing.T) {
+	t.Parallel()
+
+	currentTime := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	keyboard := NewKeyboardFormer().GenerateCalendarKeyboard("", currentTime).InlineKeyboardMarkup.InlineKeyboard
+
+	// Header, days names and five weeks.
+	if len(keyboard) != 7 {
+		t.Errorf("unexpected rows count: got: %v, want: %v", len(keyboard), 7)
+	}
+}
+
+func TestFooterActions(t *testing.T) {
+	t.Parallel()
+
+	kf := NewKeyboardFormer(
+		ChangeFooterButtons(FooterButton{Action: FooterActionToday}, FooterButton{Action: FooterActionClear}),
+		NewButtonsTextWrapper(
+			day_button_former.ChangeUnselectableDays(map[time.Time]struct{}{time.Date(2023,
+				6, 2, 0, 0, 0, 0, time.UTC): {}}),
+		),
+	)
+
+	type args struct {
+		callbackPayload string
+		currentTime     time.Time
+	}
+
+	tests := []struct {
+		name                  string
+		args                  args
+		wantSelectedDay       time.Time
+		wantIsUnselectableDay bool
+		wantIsCleared         bool
+		wantIsCancelled       bool
+		wantIsConfirmed       bool
+		wantKeyboardRows      int
+	}{
+		{
+			name: "today from another month",
+			args: args{
+				callbackPayload: "calendar/tdy_00.01.2024",
+				currentTime:     time.Date(2023, 6, 1, 15, 0, 0, 0, time.UTC),
+			},
+			wantSelectedDay: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "today is unselectable",
+			args: args{
+				callbackPayload: "calendar/tdy_00.06.2023",
+				currentTime:     time.Date(2023, 6, 2, 15, 0, 0, 0, time.UTC),
+			},
+			wantSelectedDay:       time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC),
+			wantIsUnselectableDay: true,
+		},
+		{
+			name: "clear keeps the calendar",
+			args: args{
+				callbackPayload: "calendar/clr_00.06.2023",
+				currentTime:     time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+			},
+			wantIsCleared:    true,
+			wantKeyboardRows: 8,
+		},
+		{
+			name: "cancel",
+			args: args{
+				callbackPayload: "calendar/cnl_00.06.2023",
+				currentTime:     time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+			},
+			wantIsCancelled: true,
+		},
+		{
+			name: "confirm",
+			args: args{
+				callbackPayload: "calendar/cfm_00.06.2023",
+				currentTime:     time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+			},
+			wantIsConfirmed: true,
+		},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			response := kf.GenerateCalendarKeyboard(tt.args.callbackPayload, tt.args.currentTime)
+			if !response.SelectedDay.Equal(tt.wantSelectedDay) {
+				t.Errorf("unexpected selected day: got: %v, want: %v", response.SelectedDay, tt.wantSelectedDay)
+			}
+			if response.IsUnselectableDay != tt.wantIsUnselectableDay {
+				t.Errorf("unexpected IsUnselectableDay: got: %v, want: %v", response.IsUnselectableDay, tt.wantIsUnselectableDay)
+			}
+			if response.IsCleared != tt.wantIsCleared {
+				t.Errorf("unexpected IsCleared: got: %v, want: %v", response.IsCleared, tt.wantIsCleared)
+			}
+			if response.IsCancelled != tt.wantIsCancelled {
+				t.Errorf("unexpected IsCancelled: got: %v, want: %v", response.IsCancelled, tt.wantIsCancelled)
+			}
+			if response.IsConfirmed != tt.wantIsConfirmed {
+				t.Errorf("unexpected IsConfirmed: got: %v, want: %v", response.IsConfirmed, tt.wantIsConfirmed)
+			}
+			if len(response.InlineKeyboardMarkup.InlineKeyboard) != tt.wantKeyboardRows {
+				t.Errorf("unexpected keyboard rows: got: %v, want: %v", len(response.InlineKeyboardMarkup.InlineKeyboard), tt.wantKeyboardRows)
+			}
+		},
+		)
+	}
+}
+
+func TestFooterTodayAtGeneratorTimezone(t *testing.T) {
+	t.Parallel()
+
+	tzAsiaT, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Errorf("at time.LoadLocation for Asia/Tokyo error: %v", err)
+		return
+	}
+	kf := NewKeyboardFormer(
+		ChangeFooterButtons(FooterButton{Action: FooterActionToday}),
+		NewButtonsTextWrapper(day_button_former.ChangeTimezone(tzAsiaT)),
+	)
+	// It is the 14th at UTC, but already the 15th at Tokyo.
+	currentTime := time.Date(2023, 6, 14, 20, 0, 0, 0, time.UTC)
+
+	for _, row := range kf.GenerateCalendarKeyboard("", currentTime).InlineKeyboardMarkup.InlineKeyboard {
+		for _, button := range row {
+			if button.CallbackData == "calendar/sed_15.06.2023" && button.Text != "15🗓" {
+				t.Errorf("the 15th is not marked as the current day: %v", button.Text)
+			}
+		}
+	}
+
+	selectedDay := kf.GenerateCalendarKeyboard("calendar/tdy_00.06.2023", currentTime).SelectedDay
+	if want := time.Date(2023, 6, 15, 0, 0, 0, 0, tzAsiaT); !selectedDay.Equal(want) {
+		t.Errorf("unexpected selected day: got: %v, want: %v", selectedDay, want)
+	}
+}
+
+func TestFooterButtonsAtConfigAreCopied(t *testing.T) {
+	t.Parallel()
+
+	kf := NewKeyboardFormer(ChangeFooterButtons(FooterButton{Action: FooterActionCancel}))
+
+	config := kf.GetCurrentConfig()
+	config.FooterButtons[0].Text = "changed"
+
+	if kf.GetCurrentConfig().FooterButtons[0].Text != "" {
+		t.Errorf("footer buttons changed through config: %v", kf.GetCurrentConfig().FooterButtons)
+	}
+}
@@ -8,6 +8,8 @@ import (
 
 // GenerateCurrentMonth ...
 func (k *KeyboardFormer) GenerateCurrentMonth(month, year int, currentTime time.Time) [][]models.InlineKeyboardButton {
+	k = k.withMonthEvents(month, year)
+
 	monthStart := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
 	monthEnd := monthStart.AddDate(0, 1, -1)
 
@@ -28,6 +30,16 @@ func (k *KeyboardFormer) GenerateCurrentMonth(month, year int, currentTime time.
 	rowLastWeek := k.generateLastWeek(month, year, dayNumber, monthEnd, currentTime)
 	rowWeeks = append(rowWeeks, rowLastWeek)
 
+	if k.fixedWeeksRows {
+		// Continue after the last week padding.
+		rowWeeks = append(rowWeeks, k.generatePaddingWeeks(month, year, monthEnd.Day()+daysInWeek-getWeekDay(monthEnd)+1,
+			maxWeeksInMonth-len(rowWeeks), currentTime)...)
+	}
+
+	for _, rowWeek := range rowWeeks {
+		k.mirrorRowIfNeeded(rowWeek)
+	}
+
 	return rowWeeks
 }
 
@@ -62,16 +74,14 @@ func (k *KeyboardFormer) generateFirstWeek(month, year int, weekday int, current
 	rowFirstWeek := make([]models.InlineKeyboardButton, 0, standardButtonsAtRow)
 	totalWeekDaysAtStart := 0
 	for wd := 1; wd < weekday; wd++ {
-		btn := models.NewInlineKeyboardButton(emptyText, k.payloadEncoderDecoder.Encoding(silentDoNothingAction, 0, month, year))
-		rowFirstWeek = append(rowFirstWeek, btn)
+		// Day 0 is the last day of the previous month.
+		rowFirstWeek = append(rowFirstWeek, k.paddingDayButton(wd-weekday+1, month, year, currentTime))
 		totalWeekDaysAtStart++
 	}
 
 	// Buttons with the numbers of the first week.
 	for wd := weekday; wd <= daysInWeek; wd++ {
-		btnText, isUnselectableDay := k.buttonsTextWrapper.DayButtonTextWrapper(dayNumber, month, year, currentTime)
-		btn := models.NewInlineKeyboardButton(btnText, k.payloadEncoderDecoder.Encoding(chooseAction(isUnselectableDay), dayNumber, month, year))
-		rowFirstWeek = append(rowFirstWeek, btn)
+		rowFirstWeek = append(rowFirstWeek, k.monthDayButton(dayNumber, month, year, currentTime))
 		dayNumber++
 	}
 
@@ -89,9 +99,7 @@ func (k *KeyboardFormer) generateMiddleWeeks(
 
 		// Filling in the dates.
 		for cw := 1; cw <= daysInWeek; cw++ {
-			btnText, isUnselectableDay := k.buttonsTextWrapper.DayButtonTextWrapper(dayNumber, month, year, currentTime)
-			btn := models.NewInlineKeyboardButton(btnText, k.payloadEncoderDecoder.Encoding(chooseAction(isUnselectableDay), dayNumber, month, year))
-			rowCurrentWeek = append(rowCurrentWeek, btn)
+			rowCurrentWeek = append(rowCurrentWeek, k.monthDayButton(dayNumber, month, year, currentTime))
 			dayNumber++
 		}
 
@@ -110,20 +118,71 @@ func (k *KeyboardFormer) generateLastWeek(month, year int, dayNumber int,
 	endMonthDay := monthEnd.Day()
 
 	for wd := dayNumber; wd <= endMonthDay; wd++ {
-		btnText, isUnselectableDay := k.buttonsTextWrapper.DayButtonTextWrapper(wd, month, year, currentTime)
-		btn := models.NewInlineKeyboardButton(btnText, k.payloadEncoderDecoder.Encoding(chooseAction(isUnselectableDay), wd, month, year))
-		rowLastWeek = append(rowLastWeek, btn)
+		rowLastWeek = append(rowLastWeek, k.monthDayButton(wd, month, year, currentTime))
 	}
 
 	// Fill the last week with blank buttons.
 	for wd := monthEndWeekday + 1; wd <= daysInWeek; wd++ {
-		btn := models.NewInlineKeyboardButton(emptyText, k.payloadEncoderDecoder.Encoding(silentDoNothingAction, 0, month, year))
-		rowLastWeek = append(rowLastWeek, btn)
+		rowLastWeek = append(rowLastWeek, k.paddingDayButton(endMonthDay+wd-monthEndWeekday, month, year, currentTime))
 	}
 
 	return rowLastWeek
 }
 
+// The day of the month. Selectable days with events open the events list instead of the selection.
+func (k *KeyboardFormer) monthDayButton(day, month, year int, currentTime time.Time) models.InlineKeyboardButton {
+	params := k.dayButtonParams()
+	eventsCount := k.monthEventsCount[day]
+	if eventsCount > 0 {
+		params.EventsMarker = k.eventsMarker(eventsCount)
+	}
+	btnText, isUnselectableDay := k.dayButtonTextWithParams(day, month, year, currentTime, params)
+
+	action := chooseAction(isUnselectableDay)
+	if eventsCount > 0 && !isUnselectableDay {
+		action = eventsDayAction
+	}
+
+	return models.NewInlineKeyboardButton(btnText, k.payloadEncoderDecoder.Encoding(action, day, month, year))
+}
+
+// Blank button or the day of the adjacent month, depends on adjacentDaysMode.
+// The day is out of the month range (0 and below, or after the last day) and is normalized like time.Date does.
+func (k *KeyboardFormer) paddingDayButton(day, month, year int, currentTime time.Time) models.InlineKeyboardButton {
+	if k.adjacentDaysMode == AdjacentDaysHidden {
+		return models.NewInlineKeyboardButton(emptyText, k.payloadEncoderDecoder.Encoding(silentDoNothingAction, 0, month, year))
+	}
+
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	day, month, year = date.Day(), int(date.Month()), date.Year()
+
+	params := k.dayButtonParams()
+	params.IsAdjacentMonth = true
+	btnText, isUnselectableDay := k.dayButtonTextWithParams(day, month, year, currentTime, params)
+
+	action := chooseAction(isUnselectableDay)
+	if k.adjacentDaysMode == AdjacentDaysNavigate {
+		action = showSelectedAction
+	}
+
+	return models.NewInlineKeyboardButton(btnText, k.payloadEncoderDecoder.Encoding(action, day, month, year))
+}
+
+// Whole weeks after the month, for the stable keyboard height.
+func (k *KeyboardFormer) generatePaddingWeeks(month, year, dayNumber, weeksCount int,
+	currentTime time.Time) [][]models.InlineKeyboardButton {
+	paddingWeeks := make([][]models.InlineKeyboardButton, 0, weeksCount)
+	for rowWeek := 0; rowWeek < weeksCount; rowWeek++ {
+		rowCurrentWeek := make([]models.InlineKeyboardButton, 0, standardButtonsAtRow)
+		for cw := 1; cw <= daysInWeek; cw++ {
+			rowCurrentWeek = append(rowCurrentWeek, k.paddingDayButton(dayNumber, month, year, currentTime))
+			dayNumber++
+		}
+		paddingWeeks = append(paddingWeeks, rowCurrentWeek)
+	}
+	return paddingWeeks
+}
+
 func chooseAction(isUnselectableDay bool) string {
 	if isUnselectableDay {
 		return unselectableDaySelected
@@ -0,0 +1,50 @@
+package generator
+
+import (
+	"time"
+
+	"github.com/thevan4/telegram-calendar/models"
+)
+
+// HeaderLayout is the layout of the navigation rows above the calendar, months and years keyboards.
+type HeaderLayout int
+
+// Header layouts.
+const (
+	// HeaderLayoutFull one row: «, <, Month, home, Year, >, ». The default.
+	HeaderLayoutFull HeaderLayout = iota
+	// HeaderLayoutTwoRows years row «, Year, » and months row <, Month, home, >.
+	HeaderLayoutTwoRows
+	// HeaderLayoutCompact one row <, Month, Year, > without years jumps and home button.
+	HeaderLayoutCompact
+)
+
+func (k *KeyboardFormer) generateHeaderRows(
+	month, year int,
+	currentTime time.Time,
+	needShowSelectedMonth, needShowSelectedYear bool,
+) [][]models.InlineKeyboardButton {
+	switch k.headerLayout {
+	case HeaderLayoutTwoRows:
+		btnPrevMonth, btnNextMonth, btnMonth := k.getMonthsButtons(month, year, needShowSelectedMonth)
+		btnPrevYear, btnNextYear, btnYear := k.getYearsButtons(month, year, needShowSelectedYear)
+
+		rowYears := []models.InlineKeyboardButton{btnPrevYear, btnYear, btnNextYear}
+		rowMonths := make([]models.InlineKeyboardButton, 0, 4) //nolint:gomnd // <, Month, home, >.
+		rowMonths = append(rowMonths, btnPrevMonth, btnMonth)
+		if !k.hideHomeButton {
+			rowMonths = append(rowMonths, k.formBtnBeauty(month, year, currentTime))
+		}
+		rowMonths = append(rowMonths, btnNextMonth)
+
+		return [][]models.InlineKeyboardButton{k.mirrorRowIfNeeded(rowYears), k.mirrorRowIfNeeded(rowMonths)}
+	case HeaderLayoutCompact:
+		btnPrevMonth, btnNextMonth, btnMonth := k.getMonthsButtons(month, year, needShowSelectedMonth)
+		_, _, btnYear := k.getYearsButtons(month, year, needShowSelectedYear)
+
+		row := []models.InlineKeyboardButton{btnPrevMonth, btnMonth, btnYear, btnNextMonth}
+		return [][]models.InlineKeyboardButton{k.mirrorRowIfNeeded(row)}
+	default:
+		return [][]models.InlineKeyboardButton{k.generateMonthYearRow(month, year, currentTime, needShowSelectedMonth, needShowSelectedYear)}
+	}
+}
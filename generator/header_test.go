@@ -0,0 +1,92 @@
+package generator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thevan4/telegram-calendar/models"
+)
+
+func TestGenerateHeaderRows(t *testing.T) {
+	t.Parallel()
+
+	currentTime := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		options []func(KeyboardGenerator) KeyboardGenerator
+		want    [][]models.InlineKeyboardButton
+	}{
+		{
+			name:    "full without home button",
+			options: []func(KeyboardGenerator) KeyboardGenerator{ChangeHideHomeButton(true)},
+			want: [][]models.InlineKeyboardButton{{
+				{Text: "«", CallbackData: "calendar/pry_00.06.2023"},
+				{Text: "<", CallbackData: "calendar/prm_00.06.2023"},
+				{Text: "Jun", CallbackData: "calendar/sem_00.06.2023"},
+				{Text: "2023", CallbackData: "calendar/sey_00.06.2023"},
+				{Text: ">", CallbackData: "calendar/nem_00.06.2023"},
+				{Text: "»", CallbackData: "calendar/ney_00.06.2023"},
+			}},
+		},
+		{
+			name: "two rows with custom names",
+			options: []func(KeyboardGenerator) KeyboardGenerator{
+				ChangeHeaderLayout(HeaderLayoutTwoRows),
+				ChangePrevMonthName("←"), ChangeNextMonthName("→"), ChangePrevYearName("⇇"), ChangeNextYearName("⇉"),
+			},
+			want: [][]models.InlineKeyboardButton{
+				{
+					{Text: "⇇", CallbackData: "calendar/pry_00.06.2023"},
+					{Text: "2023", CallbackData: "calendar/sey_00.06.2023"},
+					{Text: "⇉", CallbackData: "calendar/ney_00.06.2023"},
+				},
+				{
+					{Text: "←", CallbackData: "calendar/prm_00.06.2023"},
+					{Text: "Jun", CallbackData: "calendar/sem_00.06.2023"},
+					{Text: "🏩", CallbackData: "calendar/_00.05.2023"},
+					{Text: "→", CallbackData: "calendar/nem_00.06.2023"},
+				},
+			},
+		},
+		{
+			name:    "compact",
+			options: []func(KeyboardGenerator) KeyboardGenerator{ChangeHeaderLayout(HeaderLayoutCompact)},
+			want: [][]models.InlineKeyboardButton{{
+				{Text: "<", CallbackData: "calendar/prm_00.06.2023"},
+				{Text: "Jun", CallbackData: "calendar/sem_00.06.2023"},
+				{Text: "2023", CallbackData: "calendar/sey_00.06.2023"},
+				{Text: ">", CallbackData: "calendar/nem_00.06.2023"},
+			}},
+		},
+		{
+			name: "compact right-to-left",
+			options: []func(KeyboardGenerator) KeyboardGenerator{
+				ChangeHeaderLayout(HeaderLayoutCompact), ChangeRightToLeft(true),
+			},
+			want: [][]models.InlineKeyboardButton{{
+				{Text: "<", CallbackData: "calendar/nem_00.06.2023"},
+				{Text: "2023", CallbackData: "calendar/sey_00.06.2023"},
+				{Text: "Jun", CallbackData: "calendar/sem_00.06.2023"},
+				{Text: ">", CallbackData: "calendar/prm_00.06.2023"},
+			}},
+		},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			kf, _ := newDefaultKeyboardFormer().ApplyNewOptions(tt.options...).(*KeyboardFormer)
+			keyboard := kf.GenerateCalendar(6, 2023, currentTime).InlineKeyboard
+			if !isSlicesOfSlicesEqual(keyboard[:len(tt.want)], tt.want) {
+				t.Errorf("unexpected header: got: %v, want: %v", keyboard[:len(tt.want)], tt.want)
+			}
+			// Days names row follows the header.
+			if keyboard[len(tt.want)][0].CallbackData != "calendar/sdn_00.06.2023" {
+				t.Errorf("unexpected row after the header: %v", keyboard[len(tt.want)])
+			}
+		},
+		)
+	}
+}
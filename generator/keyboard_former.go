@@ -1,7 +1,11 @@
 package generator
 
 import (
+	"context"
+	"time"
+
 	"github.com/thevan4/telegram-calendar/day_button_former"
+	"github.com/thevan4/telegram-calendar/models"
 	"github.com/thevan4/telegram-calendar/payload_former"
 )
 
@@ -13,8 +17,36 @@ type KeyboardFormer struct {
 	daysNames             [7]string
 	monthNames            [12]string
 	homeButtonForBeauty   string
+	hideHomeButton        bool
+	headerLayout          HeaderLayout
+	prevMonthName         string
+	nextMonthName         string
+	prevYearName          string
+	nextYearName          string
 	payloadEncoderDecoder payload_former.PayloadEncoderDecoder
 	buttonsTextWrapper    day_button_former.DaysButtonsText
+	rightToLeft           bool
+	footerButtons         []FooterButton
+	extraRowsAbove        [][]models.InlineKeyboardButton
+	extraRowsBelow        [][]models.InlineKeyboardButton
+	adjacentDaysMode      AdjacentDaysMode
+	fixedWeeksRows        bool
+	hideDaysNames         bool
+	eventSource           EventSource
+	eventMarker           string
+	showEventsCount       bool
+	// Render only settings, see RenderSettings.
+	userLocation              *time.Location
+	selectedDayInUserLocation bool
+	preselectedDate           time.Time
+	// The context for the providers, see WithContext; nil for the renders without it.
+	renderCtx context.Context
+	// The render context has a tracer, see startRenderSpan.
+	traced bool
+	// The providers errors of the render with the context, see render; nil for the renders without it.
+	renderErrs *renderErrors
+	// Month render only, see withMonthEvents.
+	monthEventsCount map[int]int
 }
 
 // NewKeyboardFormer maker for KeyboardFormer.
@@ -32,6 +64,11 @@ func newDefaultKeyboardFormer() *KeyboardFormer {
 		daysNames:             daysNamesDefault,
 		monthNames:            monthNamesDefault,
 		homeButtonForBeauty:   emojiForBeautyDefault,
+		prevMonthName:         prevMonthActionName,
+		nextMonthName:         nextMonthActionName,
+		prevYearName:          prevYearActionName,
+		nextYearName:          nextYearActionName,
+		eventMarker:           eventMarkerDefault,
 		payloadEncoderDecoder: payload_former.NewEncoderDecoder(),
 		buttonsTextWrapper:    day_button_former.NewButtonsFormer(),
 	}
@@ -42,10 +79,13 @@ func NewButtonsTextWrapper(
 	options ...func(day_button_former.DaysButtonsText) day_button_former.DaysButtonsText,
 ) func(KeyboardGenerator) KeyboardGenerator {
 	return func(kg KeyboardGenerator) KeyboardGenerator {
-		if k, ok := kg.(*KeyboardFormer); ok {
-			k.buttonsTextWrapper = day_button_former.NewButtonsFormer(options...)
-			return k
-		}
-		return kg
+		return configure(kg, func(k *KeyboardFormer) {
+			// The numeral system is shared with the days labels.
+			k.buttonsTextWrapper = day_button_former.NewButtonsFormer(
+				append([]func(day_button_former.DaysButtonsText) day_button_former.DaysButtonsText{
+					day_button_former.ChangeNumeralSystem(k.getNumeralSystem()),
+				}, options...)...,
+			)
+		})
 	}
 }
@@ -0,0 +1,58 @@
+package generator
+
+import (
+	"strings"
+	"sync"
+)
+
+// Locale contains the naming of days and months for one language.
+type Locale struct {
+	DaysNames  [7]string
+	MonthNames [12]string
+}
+
+// LocaleRegistry matches telegram language_code values with locales.
+type LocaleRegistry struct {
+	sync.RWMutex
+	locales map[string]Locale
+}
+
+// NewLocaleRegistry maker for LocaleRegistry, english and russian locales are registered by default.
+func NewLocaleRegistry() *LocaleRegistry {
+	return &LocaleRegistry{
+		locales: map[string]Locale{
+			languageCodeEnglish: {DaysNames: daysNamesDefault, MonthNames: monthNamesDefault},
+			languageCodeRussian: {DaysNames: daysNamesRussian, MonthNames: monthNamesRussian},
+		},
+	}
+}
+
+// Register adds or replaces the locale for language code.
+func (lr *LocaleRegistry) Register(languageCode string, locale Locale) {
+	lr.Lock()
+	defer lr.Unlock()
+	lr.locales[normalizeLanguageCode(languageCode)] = locale
+}
+
+// Lookup finds the locale for language code. For region specific codes ("pt-br")
+// the base language ("pt") is used if there is no exact match.
+func (lr *LocaleRegistry) Lookup(languageCode string) (Locale, bool) {
+	lr.RLock()
+	defer lr.RUnlock()
+
+	languageCode = normalizeLanguageCode(languageCode)
+	if locale, ok := lr.locales[languageCode]; ok {
+		return locale, true
+	}
+
+	if baseLanguage, _, hasRegion := strings.Cut(languageCode, languageRegionSeparator); hasRegion {
+		locale, ok := lr.locales[baseLanguage]
+		return locale, ok
+	}
+
+	return Locale{}, false
+}
+
+func normalizeLanguageCode(languageCode string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(languageCode)), "_", languageRegionSeparator)
+}
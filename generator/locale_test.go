@@ -0,0 +1,81 @@
+package generator
+
+import (
+	"testing"
+)
+
+func TestLocaleRegistryLookup(t *testing.T) {
+	t.Parallel()
+
+	lr := NewLocaleRegistry()
+	ptLocale := Locale{
+		DaysNames:  [7]string{"Seg", "Ter", "Qua", "Qui", "Sex", "Sáb", "Dom"},
+		MonthNames: [12]string{"Jan", "Fev", "Mar", "Abr", "Mai", "Jun", "Jul", "Ago", "Set", "Out", "Nov", "Dez"},
+	}
+	lr.Register("PT", ptLocale)
+
+	tests := []struct {
+		name         string
+		languageCode string
+		wantLocale   Locale
+		wantFound    bool
+	}{
+		{
+			name:         "default english",
+			languageCode: "en",
+			wantLocale:   Locale{DaysNames: daysNamesDefault, MonthNames: monthNamesDefault},
+			wantFound:    true,
+		},
+		{
+			name:         "default russian",
+			languageCode: "ru",
+			wantLocale:   Locale{DaysNames: daysNamesRussian, MonthNames: monthNamesRussian},
+			wantFound:    true,
+		},
+		{
+			name:         "registered with upper case",
+			languageCode: "pt",
+			wantLocale:   ptLocale,
+			wantFound:    true,
+		},
+		{
+			name:         "fallback to base language",
+			languageCode: "pt-BR",
+			wantLocale:   ptLocale,
+			wantFound:    true,
+		},
+		{
+			name:         "fallback to base language with underscore",
+			languageCode: "en_US",
+			wantLocale:   Locale{DaysNames: daysNamesDefault, MonthNames: monthNamesDefault},
+			wantFound:    true,
+		},
+		{
+			name:         "unknown language",
+			languageCode: "fa",
+			wantLocale:   Locale{},
+			wantFound:    false,
+		},
+		{
+			name:         "empty language code",
+			languageCode: "",
+			wantLocale:   Locale{},
+			wantFound:    false,
+		},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			locale, found := lr.Lookup(tt.languageCode)
+			if found != tt.wantFound {
+				t.Errorf("at Lookup(%v) found: %v, want: %v", tt.languageCode, found, tt.wantFound)
+			}
+			if locale != tt.wantLocale {
+				t.Errorf("at Lookup(%v) locale: %v, want: %v", tt.languageCode, locale, tt.wantLocale)
+			}
+		},
+		)
+	}
+}
@@ -2,16 +2,19 @@ package generator
 
 import (
 	"github.com/thevan4/telegram-calendar/day_button_former"
+	"github.com/thevan4/telegram-calendar/models"
 	"github.com/thevan4/telegram-calendar/payload_former"
 )
 
-// ApplyNewOptions ...
+// ApplyNewOptions applies the options to a copy, the former itself is never changed,
+// so it can be shared by concurrent renders.
 func (k *KeyboardFormer) ApplyNewOptions(options ...func(KeyboardGenerator) KeyboardGenerator) KeyboardGenerator {
-	var kg KeyboardGenerator = k
+	kf := k.clone()
+	var kg KeyboardGenerator = kf
 	for _, option := range options {
 		kg = option(kg)
 	}
-	return k
+	return kf
 }
 
 // ApplyNewOptionsForButtonsTextWrapper ...
@@ -19,78 +22,237 @@ func ApplyNewOptionsForButtonsTextWrapper(
 	options ...func(day_button_former.DaysButtonsText) day_button_former.DaysButtonsText,
 ) func(KeyboardGenerator) KeyboardGenerator {
 	return func(kg KeyboardGenerator) KeyboardGenerator {
-		if k, ok := kg.(*KeyboardFormer); ok {
+		return configureWith(kg, func(k *KeyboardFormer) {
 			k.buttonsTextWrapper = k.buttonsTextWrapper.ApplyNewOptions(options...)
-			return k
-		}
-		return kg
+		}, func(k *KeyboardFormer) error {
+			return day_button_former.CheckOptions(k.buttonsTextWrapper, options...)
+		}, false, nil)
+	}
+}
+
+// ChangeDaysButtonsText replaces the days buttons former with own implementation or decorator,
+// see day_button_former.DaysButtonsTextWrapper. Nil keeps the current one.
+func ChangeDaysButtonsText(daysButtonsText day_button_former.DaysButtonsText) func(KeyboardGenerator) KeyboardGenerator {
+	return func(kg KeyboardGenerator) KeyboardGenerator {
+		return configure(kg, func(k *KeyboardFormer) {
+			if daysButtonsText != nil {
+				k.buttonsTextWrapper = daysButtonsText
+			}
+		})
 	}
 }
 
 // ChangeYearsBackForChoose ...
 func ChangeYearsBackForChoose(yearsBackForChoose int) func(KeyboardGenerator) KeyboardGenerator {
 	return func(kg KeyboardGenerator) KeyboardGenerator {
-		if k, ok := kg.(*KeyboardFormer); ok {
+		return configure(kg, func(k *KeyboardFormer) {
 			k.sumYearsForChoose = (k.sumYearsForChoose - k.yearsBackForChoose) + yearsBackForChoose
 			k.yearsBackForChoose = yearsBackForChoose
-			return k
-		}
-		return kg
+		})
 	}
 }
 
 // ChangeYearsForwardForChoose ...
 func ChangeYearsForwardForChoose(yearsForwardForChoose int) func(KeyboardGenerator) KeyboardGenerator {
 	return func(kg KeyboardGenerator) KeyboardGenerator {
-		if k, ok := kg.(*KeyboardFormer); ok {
+		return configure(kg, func(k *KeyboardFormer) {
 			k.sumYearsForChoose = (k.sumYearsForChoose - k.yearsForwardForChoose) + yearsForwardForChoose
 			k.yearsForwardForChoose = yearsForwardForChoose
-			return k
-		}
-		return kg
+		})
 	}
 }
 
 // ChangeDaysNames ...
 func ChangeDaysNames(daysNames [7]string) func(KeyboardGenerator) KeyboardGenerator {
 	return func(kg KeyboardGenerator) KeyboardGenerator {
-		if k, ok := kg.(*KeyboardFormer); ok {
+		return configure(kg, func(k *KeyboardFormer) {
 			k.daysNames = daysNames
-			return k
-		}
-		return kg
+		})
 	}
 }
 
 // ChangeMonthNames ...
 func ChangeMonthNames(monthNames [12]string) func(KeyboardGenerator) KeyboardGenerator {
 	return func(kg KeyboardGenerator) KeyboardGenerator {
-		if k, ok := kg.(*KeyboardFormer); ok {
+		return configure(kg, func(k *KeyboardFormer) {
 			k.monthNames = monthNames
-			return k
-		}
-		return kg
+		})
 	}
 }
 
 // ChangeHomeButtonForBeauty ...
 func ChangeHomeButtonForBeauty(homeButtonForBeauty string) func(KeyboardGenerator) KeyboardGenerator {
 	return func(kg KeyboardGenerator) KeyboardGenerator {
-		if k, ok := kg.(*KeyboardFormer); ok {
+		return configure(kg, func(k *KeyboardFormer) {
 			k.homeButtonForBeauty = homeButtonForBeauty
-			return k
-		}
-		return kg
+		})
+	}
+}
+
+// ChangeRightToLeft mirrors all rows for right-to-left languages.
+func ChangeRightToLeft(rightToLeft bool) func(KeyboardGenerator) KeyboardGenerator {
+	return func(kg KeyboardGenerator) KeyboardGenerator {
+		return configure(kg, func(k *KeyboardFormer) {
+			k.rightToLeft = rightToLeft
+		})
+	}
+}
+
+// ChangeNumeralSystem changes digits of the days and years labels. Callback payloads are not changed.
+// The setting is kept by the days buttons former, day_button_former.ChangeNumeralSystem does the same.
+func ChangeNumeralSystem(numeralSystem day_button_former.NumeralSystem) func(KeyboardGenerator) KeyboardGenerator {
+	return func(kg KeyboardGenerator) KeyboardGenerator {
+		return configure(kg, func(k *KeyboardFormer) {
+			k.buttonsTextWrapper = k.buttonsTextWrapper.ApplyNewOptions(day_button_former.ChangeNumeralSystem(numeralSystem))
+		})
+	}
+}
+
+// ChangeFooterButtons sets the footer row under the calendar, no buttons means no footer (default).
+func ChangeFooterButtons(footerButtons ...FooterButton) func(KeyboardGenerator) KeyboardGenerator {
+	return func(kg KeyboardGenerator) KeyboardGenerator {
+		return configure(kg, func(k *KeyboardFormer) {
+			k.footerButtons = append([]FooterButton(nil), footerButtons...)
+		})
+	}
+}
+
+// ChangeExtraRowsAbove adds caller's own rows above the calendar header at every calendar keyboard.
+// Buttons are not changed, so they may be url, web app or any other kind of buttons.
+func ChangeExtraRowsAbove(rows [][]models.InlineKeyboardButton) func(KeyboardGenerator) KeyboardGenerator {
+	return func(kg KeyboardGenerator) KeyboardGenerator {
+		return configure(kg, func(k *KeyboardFormer) {
+			k.extraRowsAbove = copyRows(rows)
+		})
+	}
+}
+
+// ChangeExtraRowsBelow adds caller's own rows at the bottom of every calendar keyboard.
+// Buttons are not changed, so they may be url, web app or any other kind of buttons.
+func ChangeExtraRowsBelow(rows [][]models.InlineKeyboardButton) func(KeyboardGenerator) KeyboardGenerator {
+	return func(kg KeyboardGenerator) KeyboardGenerator {
+		return configure(kg, func(k *KeyboardFormer) {
+			k.extraRowsBelow = copyRows(rows)
+		})
+	}
+}
+
+// ChangeAdjacentDaysMode shows days of the previous and the next month instead of blank buttons.
+// Their styling is set by day_button_former.ChangePrefixForAdjacentDay and ChangePostfixForAdjacentDay.
+func ChangeAdjacentDaysMode(mode AdjacentDaysMode) func(KeyboardGenerator) KeyboardGenerator {
+	return func(kg KeyboardGenerator) KeyboardGenerator {
+		return configure(kg, func(k *KeyboardFormer) {
+			k.adjacentDaysMode = mode
+		})
+	}
+}
+
+// ChangeFixedWeeksRows always renders six weeks rows, so the message height does not jump while navigating.
+// Extra rows are blank or days of the next month, see ChangeAdjacentDaysMode.
+func ChangeFixedWeeksRows(fixedWeeksRows bool) func(KeyboardGenerator) KeyboardGenerator {
+	return func(kg KeyboardGenerator) KeyboardGenerator {
+		return configure(kg, func(k *KeyboardFormer) {
+			k.fixedWeeksRows = fixedWeeksRows
+		})
+	}
+}
+
+// ChangeHideDaysNames drops the days names row for compact layouts.
+func ChangeHideDaysNames(hideDaysNames bool) func(KeyboardGenerator) KeyboardGenerator {
+	return func(kg KeyboardGenerator) KeyboardGenerator {
+		return configure(kg, func(k *KeyboardFormer) {
+			k.hideDaysNames = hideDaysNames
+		})
+	}
+}
+
+// ChangeHeaderLayout changes the navigation rows layout, see HeaderLayout.
+func ChangeHeaderLayout(headerLayout HeaderLayout) func(KeyboardGenerator) KeyboardGenerator {
+	return func(kg KeyboardGenerator) KeyboardGenerator {
+		return configure(kg, func(k *KeyboardFormer) {
+			k.headerLayout = headerLayout
+		})
+	}
+}
+
+// ChangeHideHomeButton removes the home button from the header.
+func ChangeHideHomeButton(hideHomeButton bool) func(KeyboardGenerator) KeyboardGenerator {
+	return func(kg KeyboardGenerator) KeyboardGenerator {
+		return configure(kg, func(k *KeyboardFormer) {
+			k.hideHomeButton = hideHomeButton
+		})
+	}
+}
+
+// ChangePrevMonthName previous month button name.
+func ChangePrevMonthName(name string) func(KeyboardGenerator) KeyboardGenerator {
+	return func(kg KeyboardGenerator) KeyboardGenerator {
+		return configure(kg, func(k *KeyboardFormer) {
+			k.prevMonthName = name
+		})
+	}
+}
+
+// ChangeNextMonthName next month button name.
+func ChangeNextMonthName(name string) func(KeyboardGenerator) KeyboardGenerator {
+	return func(kg KeyboardGenerator) KeyboardGenerator {
+		return configure(kg, func(k *KeyboardFormer) {
+			k.nextMonthName = name
+		})
+	}
+}
+
+// ChangePrevYearName previous year button name.
+func ChangePrevYearName(name string) func(KeyboardGenerator) KeyboardGenerator {
+	return func(kg KeyboardGenerator) KeyboardGenerator {
+		return configure(kg, func(k *KeyboardFormer) {
+			k.prevYearName = name
+		})
+	}
+}
+
+// ChangeNextYearName next year button name.
+func ChangeNextYearName(name string) func(KeyboardGenerator) KeyboardGenerator {
+	return func(kg KeyboardGenerator) KeyboardGenerator {
+		return configure(kg, func(k *KeyboardFormer) {
+			k.nextYearName = name
+		})
+	}
+}
+
+// ChangeEventSource marks days with events, a tap on such day returns the events instead of the selection.
+// Nil source disables the markers.
+func ChangeEventSource(eventSource EventSource) func(KeyboardGenerator) KeyboardGenerator {
+	return func(kg KeyboardGenerator) KeyboardGenerator {
+		return configure(kg, func(k *KeyboardFormer) {
+			k.eventSource = eventSource
+		})
+	}
+}
+
+// ChangeEventMarker marker added to days with events.
+func ChangeEventMarker(marker string) func(KeyboardGenerator) KeyboardGenerator {
+	return func(kg KeyboardGenerator) KeyboardGenerator {
+		return configure(kg, func(k *KeyboardFormer) {
+			k.eventMarker = marker
+		})
+	}
+}
+
+// ChangeShowEventsCount adds the number of events after the marker.
+func ChangeShowEventsCount(showEventsCount bool) func(KeyboardGenerator) KeyboardGenerator {
+	return func(kg KeyboardGenerator) KeyboardGenerator {
+		return configure(kg, func(k *KeyboardFormer) {
+			k.showEventsCount = showEventsCount
+		})
 	}
 }
 
 // ChangePayloadEncoderDecoder ...
 func ChangePayloadEncoderDecoder(payloadEncoderDecoder payload_former.PayloadEncoderDecoder) func(KeyboardGenerator) KeyboardGenerator {
 	return func(kg KeyboardGenerator) KeyboardGenerator {
-		if k, ok := kg.(*KeyboardFormer); ok {
+		return configure(kg, func(k *KeyboardFormer) {
 			k.payloadEncoderDecoder = payloadEncoderDecoder
-			return k
-		}
-		return kg
+		})
 	}
 }
@@ -193,3 +193,86 @@ func TestApplyNewOptionsForUnexpectedImpl(t *testing.T) {
 		t.Errorf("unexpected result at ApplyNewOptions for fake impl KeyboardGenerator: got: %v, want: {some val}", fmt.Sprint(fiKF))
 	}
 }
+
+func TestChangeNumeralSystem(t *testing.T) {
+	t.Parallel()
+
+	currentTime := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	kf := NewKeyboardFormer(
+		ChangeNumeralSystem(day_button_former.NumeralsPersian),
+		// Wrapper created after the numeral system change keeps it.
+		NewButtonsTextWrapper(
+			day_button_former.ChangePostfixForCurrentDay(""),
+		),
+	)
+
+	keyboard := kf.GenerateCalendarKeyboard("", currentTime).InlineKeyboardMarkup.InlineKeyboard
+	if keyboard[0][4].Text != "۲۰۲۳" {
+		t.Errorf("unexpected year text: got: %v, want: %v", keyboard[0][4].Text, "۲۰۲۳")
+	}
+	if keyboard[0][4].CallbackData != "calendar/sey_00.06.2023" {
+		t.Errorf("unexpected year callback data: got: %v, want: %v", keyboard[0][4].CallbackData, "calendar/sey_00.06.2023")
+	}
+
+	// 1 June 2023 is thursday.
+	if keyboard[2][3].Text != "۱" {
+		t.Errorf("unexpected day text: got: %v, want: %v", keyboard[2][3].Text, "۱")
+	}
+	if keyboard[2][3].CallbackData != "calendar/sed_01.06.2023" {
+		t.Errorf("unexpected day callback data: got: %v, want: %v", keyboard[2][3].CallbackData, "calendar/sed_01.06.2023")
+	}
+
+	years := kf.GenerateCalendarKeyboard("calendar/sey_00.06.2023", currentTime).InlineKeyboardMarkup.InlineKeyboard
+	if years[1][0].Text != "۲۰۲۳" || years[1][0].CallbackData != "calendar/shs_00.06.2023" {
+		t.Errorf("unexpected years row: %v", years[1])
+	}
+
+	if kf.GetCurrentConfig().NumeralSystem != day_button_former.NumeralsPersian {
+		t.Errorf("unexpected numeral system at config: %v", kf.GetCurrentConfig().NumeralSystem)
+	}
+}
+
+func TestChangeNumeralSystemOfDaysButtons(t *testing.T) {
+	t.Parallel()
+
+	// The days buttons former keeps the setting, the years labels follow it.
+	kf := NewKeyboardFormer(ApplyNewOptionsForButtonsTextWrapper(day_button_former.ChangeNumeralSystem(day_button_former.NumeralsThai)))
+
+	keyboard := kf.GenerateCalendarKeyboard("", time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)).InlineKeyboardMarkup.InlineKeyboard
+	if keyboard[0][4].Text != "๒๐๒๓" || keyboard[2][4].Text != "๒" {
+		t.Errorf("unexpected year and day texts: %v, %v", keyboard[0][4].Text, keyboard[2][4].Text)
+	}
+	if kf.GetCurrentConfig().NumeralSystem != day_button_former.NumeralsThai {
+		t.Errorf("unexpected numeral system at config: %v", kf.GetCurrentConfig().NumeralSystem)
+	}
+}
+
+func TestApplyNewOptionsCopiesGenerator(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2023, 6, 12, 0, 0, 0, 0, time.UTC)
+	kf := NewKeyboardFormer(
+		ChangeFooterButtons(FooterButton{Action: FooterActionToday}),
+		ApplyNewOptionsForButtonsTextWrapper(day_button_former.ChangeUnselectableDays(map[time.Time]struct{}{day: {}})),
+	)
+	changed := kf.ApplyNewOptions(
+		ChangeHomeButtonForBeauty("🏠"),
+		ChangeFooterButtons(FooterButton{Action: FooterActionClear}),
+		ApplyNewOptionsForButtonsTextWrapper(day_button_former.ChangeUnselectableDays(nil)),
+	)
+
+	config := kf.GetCurrentConfig()
+	if config.HomeButtonForBeauty == "🏠" || config.FooterButtons[0].Action != FooterActionToday || len(config.UnselectableDays) != 1 {
+		t.Errorf("source generator is changed: %+v", config)
+	}
+	if changedConfig := changed.GetCurrentConfig(); changedConfig.HomeButtonForBeauty != "🏠" || len(changedConfig.UnselectableDays) != 0 {
+		t.Errorf("options are not applied: %+v", changedConfig)
+	}
+
+	// The returned days are a copy.
+	kf.GetUnselectableDays()[day.AddDate(0, 0, 1)] = struct{}{}
+	config.UnselectableDays[day.AddDate(0, 0, 2)] = struct{}{}
+	if days := kf.GetUnselectableDays(); len(days) != 1 {
+		t.Errorf("generator days are changed through the returned map: %v", days)
+	}
+}
@@ -0,0 +1,138 @@
+package generator
+
+import (
+	"context"
+	"time"
+
+	"github.com/thevan4/telegram-calendar/tracing"
+)
+
+// RenderSettings contains overrides applied to a single render only.
+// The shared generator settings are never changed by them.
+type RenderSettings struct {
+	Locale      *Locale
+	RightToLeft *bool
+	// UserLocation is used for the user's "today" only, unselectable days rules use the generator timezone.
+	UserLocation *time.Location
+	// SelectedDayInUserLocation returns the selected day at the user location instead of the generator timezone.
+	SelectedDayInUserLocation bool
+	// PreselectedDate the user's previous choice, the calendar opens on its month.
+	PreselectedDate *time.Time
+	// Context the context of the render: it is passed to the providers (see ContextEventSource) and its tracer
+	// traces the render (see tracing.ContextWithTracer).
+	Context context.Context
+}
+
+// RenderOption changes RenderSettings for a single render.
+type RenderOption func(*RenderSettings)
+
+// NewRenderSettings collects render options into RenderSettings.
+func NewRenderSettings(options ...RenderOption) RenderSettings {
+	var rs RenderSettings
+	for _, option := range options {
+		option(&rs)
+	}
+	return rs
+}
+
+// WithLocale overrides days and months names for a single render.
+// Empty names (zero arrays) are not overridden.
+func WithLocale(locale Locale) RenderOption {
+	return func(rs *RenderSettings) {
+		rs.Locale = &locale
+	}
+}
+
+// WithLanguageCode overrides days and months names with the locale found at registry.
+// Does nothing if the locale is not registered.
+func WithLanguageCode(registry *LocaleRegistry, languageCode string) RenderOption {
+	return func(rs *RenderSettings) {
+		if registry == nil {
+			return
+		}
+		if locale, ok := registry.Lookup(languageCode); ok {
+			rs.Locale = &locale
+		}
+	}
+}
+
+// WithRightToLeft overrides right-to-left mode for a single render.
+func WithRightToLeft(rightToLeft bool) RenderOption {
+	return func(rs *RenderSettings) {
+		rs.RightToLeft = &rightToLeft
+	}
+}
+
+// WithUserLocation computes "today" (current day mark, default month, home button) at the user location.
+// Nil location is ignored.
+func WithUserLocation(location *time.Location) RenderOption {
+	return func(rs *RenderSettings) {
+		rs.UserLocation = location
+	}
+}
+
+// WithSelectedDayInUserLocation returns the selected day at the user location, works together with WithUserLocation.
+func WithSelectedDayInUserLocation() RenderOption {
+	return func(rs *RenderSettings) {
+		rs.SelectedDayInUserLocation = true
+	}
+}
+
+// WithPreselectedDate highlights the user's previous choice and opens the calendar on its month.
+// The home button returns to this month too. Zero date is ignored.
+func WithPreselectedDate(date time.Time) RenderOption {
+	return func(rs *RenderSettings) {
+		if date.IsZero() {
+			return
+		}
+		rs.PreselectedDate = &date
+	}
+}
+
+// WithContext passes ctx to the providers and traces the render with the tracer of ctx (see tracing.ContextWithTracer),
+// the spans are children of the ctx span. It overrides the context of GenerateCalendarKeyboardContext.
+// Nil context is ignored.
+func WithContext(ctx context.Context) RenderOption {
+	return func(rs *RenderSettings) {
+		if ctx == nil {
+			return
+		}
+		rs.Context = ctx
+	}
+}
+
+// Returns a copy of KeyboardFormer with the overrides applied, or the KeyboardFormer itself if there is nothing to override.
+func (k *KeyboardFormer) withRenderSettings(rs RenderSettings) *KeyboardFormer {
+	if rs.Locale == nil && rs.RightToLeft == nil && rs.UserLocation == nil && rs.PreselectedDate == nil &&
+		rs.Context == nil {
+		return k
+	}
+
+	kf := *k
+	if rs.Locale != nil {
+		if rs.Locale.DaysNames != [7]string{} {
+			kf.daysNames = rs.Locale.DaysNames
+		}
+		if rs.Locale.MonthNames != [12]string{} {
+			kf.monthNames = rs.Locale.MonthNames
+		}
+	}
+	if rs.RightToLeft != nil {
+		kf.rightToLeft = *rs.RightToLeft
+	}
+	if rs.UserLocation != nil {
+		kf.userLocation = rs.UserLocation
+		kf.selectedDayInUserLocation = rs.SelectedDayInUserLocation
+	}
+	if rs.PreselectedDate != nil {
+		kf.preselectedDate = *rs.PreselectedDate
+	}
+	if rs.Context != nil {
+		kf.renderCtx = rs.Context
+		kf.renderErrs = &renderErrors{}
+		_, isNoop := tracing.TracerFromContext(rs.Context).(tracing.NoopTracer)
+		kf.traced = !isNoop
+	}
+
+	return &kf
+}
@@ -0,0 +1,281 @@
+package generator
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGenerateCalendarKeyboardWithLocale(t *testing.T) {
+	t.Parallel()
+
+	kf := NewKeyboardFormer()
+	lr := NewLocaleRegistry()
+	currentTime := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		renderOptions    []RenderOption
+		wantMonthName    string
+		wantFirstDayName string
+	}{
+		{
+			name:             "without render options",
+			renderOptions:    nil,
+			wantMonthName:    "Jun",
+			wantFirstDayName: "Mo",
+		},
+		{
+			name:             "russian by language code",
+			renderOptions:    []RenderOption{WithLanguageCode(lr, "ru-RU")},
+			wantMonthName:    "Июн",
+			wantFirstDayName: "Пн",
+		},
+		{
+			name:             "unknown language code",
+			renderOptions:    []RenderOption{WithLanguageCode(lr, "xx")},
+			wantMonthName:    "Jun",
+			wantFirstDayName: "Mo",
+		},
+		{
+			name:             "nil registry",
+			renderOptions:    []RenderOption{WithLanguageCode(nil, "ru")},
+			wantMonthName:    "Jun",
+			wantFirstDayName: "Mo",
+		},
+		{
+			name: "only month names at locale",
+			renderOptions: []RenderOption{WithLocale(Locale{
+				MonthNames: [12]string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12"},
+			})},
+			wantMonthName:    "6",
+			wantFirstDayName: "Mo",
+		},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			keyboard := GenerateCalendarKeyboardWithOptions(kf, "", currentTime,
+				tt.renderOptions...).InlineKeyboardMarkup.InlineKeyboard
+			if keyboard[0][2].Text != tt.wantMonthName {
+				t.Errorf("unexpected month name: got: %v, want: %v", keyboard[0][2].Text, tt.wantMonthName)
+			}
+			if keyboard[1][0].Text != tt.wantFirstDayName {
+				t.Errorf("unexpected day name: got: %v, want: %v", keyboard[1][0].Text, tt.wantFirstDayName)
+			}
+		},
+		)
+	}
+}
+
+func TestRenderOptionsDoNotChangeSharedSettings(t *testing.T) {
+	t.Parallel()
+
+	kf := NewKeyboardFormer()
+	lr := NewLocaleRegistry()
+	currentTime := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	wg := new(sync.WaitGroup)
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			languageCode := "en"
+			if i%2 == 0 {
+				languageCode = "ru"
+			}
+			GenerateCalendarKeyboardWithOptions(kf, "", currentTime, WithLanguageCode(lr, languageCode))
+		}(i)
+	}
+	wg.Wait()
+
+	config := kf.GetCurrentConfig()
+	if config.DaysNames != daysNamesDefault {
+		t.Errorf("days names changed by render options: got: %v, want: %v", config.DaysNames, daysNamesDefault)
+	}
+	if config.MonthNames != monthNamesDefault {
+		t.Errorf("month names changed by render options: got: %v, want: %v", config.MonthNames, monthNamesDefault)
+	}
+}
+
+func TestGenerateCalendarKeyboardWithRightToLeft(t *testing.T) {
+	t.Parallel()
+
+	kf := NewKeyboardFormer()
+	currentTime := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	rtlKeyboard := GenerateCalendarKeyboardWithOptions(kf, "", currentTime,
+		WithRightToLeft(true)).InlineKeyboardMarkup.InlineKeyboard
+	if rtlKeyboard[0][0].CallbackData != "calendar/ney_00.06.2023" {
+		t.Errorf("unexpected first header button for right-to-left render: %v", rtlKeyboard[0][0])
+	}
+
+	ltrKeyboard := kf.GenerateCalendarKeyboard("", currentTime).InlineKeyboardMarkup.InlineKeyboard
+	if ltrKeyboard[0][0].CallbackData != "calendar/pry_00.06.2023" {
+		t.Errorf("right-to-left render option changed shared settings: %v", ltrKeyboard[0][0])
+	}
+}
+
+func TestGenerateCalendarKeyboardWithUserLocation(t *testing.T) {
+	t.Parallel()
+
+	tzAmericaNY, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Errorf("at time.LoadLocation for America/New_York error: %v", err)
+		return
+	}
+
+	kf := NewKeyboardFormer()
+	// 1 July at UTC, still 30 June at New York.
+	currentTime := time.Date(2023, 7, 1, 2, 0, 0, 0, time.UTC)
+
+	utcKeyboard := kf.GenerateCalendarKeyboard("", currentTime).InlineKeyboardMarkup.InlineKeyboard
+	if utcKeyboard[0][2].Text != "Jul" {
+		t.Errorf("unexpected default month without user location: got: %v, want: %v", utcKeyboard[0][2].Text, "Jul")
+	}
+
+	userKeyboard := GenerateCalendarKeyboardWithOptions(kf, "", currentTime,
+		WithUserLocation(tzAmericaNY)).InlineKeyboardMarkup.InlineKeyboard
+	if userKeyboard[0][2].Text != "Jun" {
+		t.Errorf("unexpected default month with user location: got: %v, want: %v", userKeyboard[0][2].Text, "Jun")
+	}
+	if userKeyboard[0][3].CallbackData != "calendar/sdn_00.06.2023" {
+		t.Errorf("unexpected home button with user location: got: %v", userKeyboard[0][3])
+	}
+	// 30 June 2023 is friday at the last week.
+	lastWeek := userKeyboard[len(userKeyboard)-1]
+	if lastWeek[4].Text != "30🗓" {
+		t.Errorf("unexpected current day with user location: got: %v, want: %v", lastWeek[4].Text, "30🗓")
+	}
+
+	type args struct {
+		renderOptions []RenderOption
+	}
+
+	tests := []struct {
+		name            string
+		args            args
+		wantSelectedDay time.Time
+	}{
+		{
+			name:            "selected day at the generator timezone",
+			args:            args{renderOptions: nil},
+			wantSelectedDay: time.Date(2023, 6, 30, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:            "selected day at the generator timezone with user location",
+			args:            args{renderOptions: []RenderOption{WithUserLocation(tzAmericaNY)}},
+			wantSelectedDay: time.Date(2023, 6, 30, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:            "selected day at the user location",
+			args:            args{renderOptions: []RenderOption{WithUserLocation(tzAmericaNY), WithSelectedDayInUserLocation()}},
+			wantSelectedDay: time.Date(2023, 6, 30, 0, 0, 0, 0, tzAmericaNY),
+		},
+		{
+			name:            "selected day at the user location without user location",
+			args:            args{renderOptions: []RenderOption{WithSelectedDayInUserLocation()}},
+			wantSelectedDay: time.Date(2023, 6, 30, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			selectedDay := GenerateCalendarKeyboardWithOptions(kf, "calendar/sed_30.06.2023", currentTime,
+				tt.args.renderOptions...).SelectedDay
+			if !selectedDay.Equal(tt.wantSelectedDay) || selectedDay.Location().String() != tt.wantSelectedDay.Location().String() {
+				t.Errorf("unexpected selected day: got: %v, want: %v", selectedDay, tt.wantSelectedDay)
+			}
+		},
+		)
+	}
+}
+
+func TestGenerateCalendarKeyboardWithPreselectedDate(t *testing.T) {
+	t.Parallel()
+
+	kf := NewKeyboardFormer()
+	currentTime := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+	preselectedDate := time.Date(2023, 8, 3, 0, 0, 0, 0, time.UTC)
+
+	type wants struct {
+		monthName   string
+		homeButton  string
+		dayOfWeekTh string // the first week thursday button text.
+	}
+
+	tests := []struct {
+		name            string
+		callbackPayload string
+		renderOptions   []RenderOption
+		want            wants
+	}{
+		{
+			name: "without preselected date",
+			want: wants{monthName: "Jun", homeButton: "calendar/sdn_00.06.2023", dayOfWeekTh: "1"},
+		},
+		{
+			name:          "opens on the preselected month",
+			renderOptions: []RenderOption{WithPreselectedDate(preselectedDate)},
+			want:          wants{monthName: "Aug", homeButton: "calendar/sdn_00.08.2023", dayOfWeekTh: "3✅"},
+		},
+		{
+			name:            "home button returns to the preselected month",
+			callbackPayload: "calendar/nem_00.06.2023",
+			renderOptions:   []RenderOption{WithPreselectedDate(preselectedDate)},
+			want:            wants{monthName: "Jul", homeButton: "calendar/_00.08.2023", dayOfWeekTh: " "},
+		},
+		{
+			name:          "zero date is ignored",
+			renderOptions: []RenderOption{WithPreselectedDate(time.Time{})},
+			want:          wants{monthName: "Jun", homeButton: "calendar/sdn_00.06.2023", dayOfWeekTh: "1"},
+		},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			keyboard := GenerateCalendarKeyboardWithOptions(kf, tt.callbackPayload, currentTime,
+				tt.renderOptions...).InlineKeyboardMarkup.InlineKeyboard
+			if keyboard[0][2].Text != tt.want.monthName {
+				t.Errorf("unexpected month name: got: %v, want: %v", keyboard[0][2].Text, tt.want.monthName)
+			}
+			if keyboard[0][3].CallbackData != tt.want.homeButton {
+				t.Errorf("unexpected home button: got: %v, want: %v", keyboard[0][3].CallbackData, tt.want.homeButton)
+			}
+			if keyboard[2][3].Text != tt.want.dayOfWeekTh {
+				t.Errorf("unexpected first thursday: got: %v, want: %v", keyboard[2][3].Text, tt.want.dayOfWeekTh)
+			}
+		},
+		)
+	}
+}
+
+// Own generator without GenerateCalendarKeyboardWithOptions.
+type ownGenerator struct {
+	KeyboardGenerator
+}
+
+func TestGenerateCalendarKeyboardWithOptionsOfOwnGenerators(t *testing.T) {
+	t.Parallel()
+
+	currentTime := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	kf := NewKeyboardFormer()
+	lr := NewLocaleRegistry()
+	want := kf.GenerateCalendarKeyboard("", currentTime)
+
+	if got := GenerateCalendarKeyboardWithOptions(ownGenerator{KeyboardGenerator: kf}, "", currentTime,
+		WithLanguageCode(lr, "ru")); !reflect.DeepEqual(got, want) {
+		t.Errorf("own generator is not rendered without the options: got: %+v, want: %+v", got, want)
+	}
+	if got := GenerateCalendarKeyboardWithOptions(kf, "", currentTime,
+		WithLanguageCode(lr, "ru")); reflect.DeepEqual(got, want) {
+		t.Errorf("render options are not applied: %+v", got)
+	}
+}
@@ -0,0 +1,292 @@
+package generator
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thevan4/telegram-calendar/day_button_former"
+	"github.com/thevan4/telegram-calendar/models"
+)
+
+var (
+	timezonePayloadRegexp = regexp.MustCompile(`^timezone/([^_]+)_(\d{1,4})\.(\d{1,4})$`)
+)
+
+// TimezoneKeyboardGenerator asks the user for the timezone: a region list, then a paginated city list.
+// Also there is an optional UTC offset quick mode.
+type TimezoneKeyboardGenerator interface {
+	GenerateTimezoneKeyboard(callbackPayload string) models.GenerateTimezoneKeyboardResponse
+	ApplyNewOptions(options ...func(TimezoneKeyboardGenerator) TimezoneKeyboardGenerator) TimezoneKeyboardGenerator
+}
+
+// TimezonePicker contains settings for the timezone picker keyboards.
+// Callback payloads start with "timezone/", so they are easy to route apart from the calendar ones.
+type TimezonePicker struct {
+	regions              []TimezoneRegion
+	utcOffsetsMinutes    []int
+	citiesPerPage        int
+	showUTCOffsets       bool
+	backButtonName       string
+	utcOffsetsButtonName string
+	numeralSystem        day_button_former.NumeralSystem
+}
+
+type timezonePayloadData struct {
+	action string
+	first  int
+	second int
+}
+
+// NewTimezonePicker maker for TimezonePicker.
+func NewTimezonePicker(
+	options ...func(TimezoneKeyboardGenerator) TimezoneKeyboardGenerator,
+) TimezoneKeyboardGenerator {
+	return newDefaultTimezonePicker().ApplyNewOptions(options...)
+}
+
+func newDefaultTimezonePicker() *TimezonePicker {
+	return &TimezonePicker{
+		regions:              timezoneRegionsDefault,
+		utcOffsetsMinutes:    utcOffsetsMinutesDefault,
+		citiesPerPage:        timezoneCitiesPerPageDefault,
+		showUTCOffsets:       true,
+		backButtonName:       timezoneBackButtonNameDefault,
+		utcOffsetsButtonName: utcOffsetsButtonNameDefault,
+		numeralSystem:        day_button_former.NumeralsLatin,
+	}
+}
+
+// GenerateTimezoneKeyboard returns the next keyboard of the flow or the selected location.
+func (tp *TimezonePicker) GenerateTimezoneKeyboard(callbackPayload string) models.GenerateTimezoneKeyboardResponse {
+	incomePayload := decodeTimezonePayload(callbackPayload)
+
+	switch incomePayload.action {
+	case showTimezoneRegionAction:
+		return models.GenerateTimezoneKeyboardResponse{
+			InlineKeyboardMarkup: tp.GenerateRegionCities(incomePayload.first, incomePayload.second),
+		}
+	case selectTimezoneAction:
+		location, zone := tp.loadRegionLocation(incomePayload.first, incomePayload.second)
+		if location != nil {
+			return models.GenerateTimezoneKeyboardResponse{SelectedLocation: location}
+		}
+		if zone != "" {
+			return models.GenerateTimezoneKeyboardResponse{InlineKeyboardMarkup: tp.GenerateRegions(), UnknownZone: zone}
+		}
+	case showUTCOffsetsAction:
+		if tp.showUTCOffsets {
+			return models.GenerateTimezoneKeyboardResponse{InlineKeyboardMarkup: tp.GenerateUTCOffsets()}
+		}
+	case selectUTCOffsetAction:
+		offsetMinutes := incomePayload.first - utcOffsetBiasMinutes
+		if tp.showUTCOffsets && offsetMinutes >= minUTCOffsetMinutes && offsetMinutes <= maxUTCOffsetMinutes {
+			return models.GenerateTimezoneKeyboardResponse{
+				SelectedLocation: utcOffsetLocation(offsetMinutes),
+			}
+		}
+	case timezoneDoNothingAction:
+		return models.GenerateTimezoneKeyboardResponse{}
+	}
+
+	return models.GenerateTimezoneKeyboardResponse{InlineKeyboardMarkup: tp.GenerateRegions()}
+}
+
+// GenerateRegions first keyboard of the flow.
+func (tp *TimezonePicker) GenerateRegions() models.InlineKeyboardMarkup {
+	buttons := make([]models.InlineKeyboardButton, 0, len(tp.regions))
+	for i, region := range tp.regions {
+		buttons = append(buttons, models.NewInlineKeyboardButton(region.Name, encodeTimezonePayload(showTimezoneRegionAction, i, 0)))
+	}
+
+	var keyboard models.InlineKeyboardMarkup
+	keyboard.InlineKeyboard = splitIntoRows(buttons, timezoneRegionsAtRow)
+
+	if tp.showUTCOffsets {
+		keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, []models.InlineKeyboardButton{
+			models.NewInlineKeyboardButton(tp.utcOffsetsButtonName, encodeTimezonePayload(showUTCOffsetsAction, 0, 0)),
+		})
+	}
+
+	return keyboard
+}
+
+// GenerateRegionCities one page of the region cities with the navigation row.
+func (tp *TimezonePicker) GenerateRegionCities(regionIndex, page int) models.InlineKeyboardMarkup {
+	if regionIndex < 0 || regionIndex >= len(tp.regions) {
+		return tp.GenerateRegions()
+	}
+
+	var keyboard models.InlineKeyboardMarkup
+	zones := tp.regions[regionIndex].Zones
+
+	pagesCount := (len(zones) + tp.citiesPerPage - 1) / tp.citiesPerPage
+	if page >= pagesCount {
+		page = pagesCount - 1
+	}
+	if page < 0 {
+		page = 0
+	}
+
+	pageStart := page * tp.citiesPerPage
+	pageEnd := pageStart + tp.citiesPerPage
+	if pageEnd > len(zones) {
+		pageEnd = len(zones)
+	}
+
+	buttons := make([]models.InlineKeyboardButton, 0, pageEnd-pageStart)
+	for zoneIndex := pageStart; zoneIndex < pageEnd; zoneIndex++ {
+		btn := models.NewInlineKeyboardButton(cityName(zones[zoneIndex]),
+			encodeTimezonePayload(selectTimezoneAction, regionIndex, zoneIndex))
+		buttons = append(buttons, btn)
+	}
+
+	keyboard.InlineKeyboard = splitIntoRows(buttons, timezoneCitiesAtRow)
+
+	keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, tp.regionNavigationRow(regionIndex, page, pagesCount))
+
+	return keyboard
+}
+
+func (tp *TimezonePicker) regionNavigationRow(regionIndex, page, pagesCount int) []models.InlineKeyboardButton {
+	btnPrev := models.NewInlineKeyboardButton(emptyText, encodeTimezonePayload(timezoneDoNothingAction, 0, 0))
+	if page > 0 {
+		btnPrev = models.NewInlineKeyboardButton(prevTimezonePageActionName,
+			encodeTimezonePayload(showTimezoneRegionAction, regionIndex, page-1))
+	}
+
+	btnNext := models.NewInlineKeyboardButton(emptyText, encodeTimezonePayload(timezoneDoNothingAction, 0, 0))
+	if page < pagesCount-1 {
+		btnNext = models.NewInlineKeyboardButton(nextTimezonePageActionName,
+			encodeTimezonePayload(showTimezoneRegionAction, regionIndex, page+1))
+	}
+
+	btnBack := models.NewInlineKeyboardButton(tp.backButtonName, encodeTimezonePayload(showTimezoneRegionsAction, 0, 0))
+
+	return []models.InlineKeyboardButton{btnPrev, btnBack, btnNext}
+}
+
+// GenerateUTCOffsets quick mode keyboard with fixed offsets.
+func (tp *TimezonePicker) GenerateUTCOffsets() models.InlineKeyboardMarkup {
+	buttons := make([]models.InlineKeyboardButton, 0, len(tp.utcOffsetsMinutes))
+	for _, offsetMinutes := range tp.utcOffsetsMinutes {
+		btn := models.NewInlineKeyboardButton(tp.utcOffsetName(offsetMinutes),
+			encodeTimezonePayload(selectUTCOffsetAction, offsetMinutes+utcOffsetBiasMinutes, 0))
+		buttons = append(buttons, btn)
+	}
+
+	var keyboard models.InlineKeyboardMarkup
+	keyboard.InlineKeyboard = splitIntoRows(buttons, utcOffsetsAtRow)
+
+	keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, []models.InlineKeyboardButton{
+		models.NewInlineKeyboardButton(tp.backButtonName, encodeTimezonePayload(showTimezoneRegionsAction, 0, 0)),
+	})
+
+	return keyboard
+}
+
+func splitIntoRows(buttons []models.InlineKeyboardButton, buttonsAtRow int) [][]models.InlineKeyboardButton {
+	rows := make([][]models.InlineKeyboardButton, 0, (len(buttons)+buttonsAtRow-1)/buttonsAtRow)
+	for len(buttons) > buttonsAtRow {
+		rows = append(rows, buttons[:buttonsAtRow:buttonsAtRow])
+		buttons = buttons[buttonsAtRow:]
+	}
+	if len(buttons) > 0 {
+		rows = append(rows, buttons)
+	}
+	return rows
+}
+
+// The location of the zone button, nil with the zone name if it is not loaded. Both are empty for unknown buttons.
+func (tp *TimezonePicker) loadRegionLocation(regionIndex, zoneIndex int) (*time.Location, string) {
+	if regionIndex >= len(tp.regions) || zoneIndex >= len(tp.regions[regionIndex].Zones) {
+		return nil, ""
+	}
+
+	zone := tp.regions[regionIndex].Zones[zoneIndex]
+	location, err := time.LoadLocation(zone)
+	if err != nil {
+		return nil, zone
+	}
+
+	return location, ""
+}
+
+// "UTC+5:30" with the digits of the numeral system.
+func (tp *TimezonePicker) utcOffsetName(offsetMinutes int) string {
+	if offsetMinutes == 0 {
+		return utcName
+	}
+
+	sb := new(strings.Builder)
+	sb.WriteString(utcName)
+	if offsetMinutes < 0 {
+		sb.WriteString("-")
+		offsetMinutes = -offsetMinutes
+	} else {
+		sb.WriteString("+")
+	}
+	sb.WriteString(tp.numeralSystem.Format(offsetMinutes / minutesInHour))
+	if minutes := offsetMinutes % minutesInHour; minutes != 0 {
+		sb.WriteString(":")
+		sb.WriteString(tp.numeralSystem.Format(minutes))
+	}
+
+	return sb.String()
+}
+
+// Location name is always ASCII: "UTC+05:30".
+func utcOffsetLocation(offsetMinutes int) *time.Location {
+	if offsetMinutes == 0 {
+		return time.UTC
+	}
+
+	sign := "+"
+	absOffsetMinutes := offsetMinutes
+	if offsetMinutes < 0 {
+		sign = "-"
+		absOffsetMinutes = -offsetMinutes
+	}
+	name := utcName + sign + twoDigits(absOffsetMinutes/minutesInHour) + ":" + twoDigits(absOffsetMinutes%minutesInHour)
+
+	return time.FixedZone(name, offsetMinutes*secondsInMinute)
+}
+
+func twoDigits(v int) string {
+	if v < 10 { //nolint:gomnd //move to the next digit.
+		return "0" + strconv.Itoa(v)
+	}
+	return strconv.Itoa(v)
+}
+
+// "America/Argentina/Buenos_Aires" -> "Buenos Aires".
+func cityName(zone string) string {
+	if i := strings.LastIndex(zone, "/"); i >= 0 {
+		zone = zone[i+1:]
+	}
+	return strings.ReplaceAll(zone, "_", " ")
+}
+
+func encodeTimezonePayload(action string, first, second int) string {
+	return timezoneCallback + "/" + action + "_" + strconv.Itoa(first) + "." + strconv.Itoa(second)
+}
+
+func decodeTimezonePayload(input string) timezonePayloadData {
+	match := timezonePayloadRegexp.FindStringSubmatch(input)
+	if len(match) != 4 { //nolint:gomnd // full match and three groups.
+		return timezonePayloadData{}
+	}
+
+	first, errFirst := strconv.Atoi(match[2])
+	second, errSecond := strconv.Atoi(match[3])
+	if errFirst != nil || errSecond != nil {
+		return timezonePayloadData{} // silence any error.
+	}
+
+	return timezonePayloadData{
+		action: match[1],
+		first:  first,
+		second: second,
+	}
+}
@@ -0,0 +1,107 @@
+package generator
+
+import (
+	"github.com/thevan4/telegram-calendar/day_button_former"
+)
+
+// ApplyNewOptions returns the new picker with the options, the receiver is not changed.
+func (tp *TimezonePicker) ApplyNewOptions(
+	options ...func(TimezoneKeyboardGenerator) TimezoneKeyboardGenerator,
+) TimezoneKeyboardGenerator {
+	picker := tp.clone()
+	var tkg TimezoneKeyboardGenerator = picker
+	for _, option := range options {
+		tkg = option(tkg)
+	}
+	return picker
+}
+
+func (tp *TimezonePicker) clone() *TimezonePicker {
+	picker := *tp
+	picker.regions = copyTimezoneRegions(tp.regions)
+	picker.utcOffsetsMinutes = append([]int(nil), tp.utcOffsetsMinutes...)
+	return &picker
+}
+
+func copyTimezoneRegions(regions []TimezoneRegion) []TimezoneRegion {
+	if regions == nil {
+		return nil
+	}
+
+	regionsCopy := make([]TimezoneRegion, 0, len(regions))
+	for _, region := range regions {
+		regionsCopy = append(regionsCopy, TimezoneRegion{Name: region.Name, Zones: append([]string(nil), region.Zones...)})
+	}
+	return regionsCopy
+}
+
+// ChangeTimezonePickerRegions replaces the regions and their zones, zones must be IANA names.
+// The regions are copied, later changes of the slice do not change the picker.
+func ChangeTimezonePickerRegions(regions []TimezoneRegion) func(TimezoneKeyboardGenerator) TimezoneKeyboardGenerator {
+	return func(tkg TimezoneKeyboardGenerator) TimezoneKeyboardGenerator {
+		if tp, ok := tkg.(*TimezonePicker); ok {
+			tp.regions = copyTimezoneRegions(regions)
+			return tp
+		}
+		return tkg
+	}
+}
+
+// ChangeTimezonePickerCitiesPerPage non-positive values are ignored.
+func ChangeTimezonePickerCitiesPerPage(citiesPerPage int) func(TimezoneKeyboardGenerator) TimezoneKeyboardGenerator {
+	return func(tkg TimezoneKeyboardGenerator) TimezoneKeyboardGenerator {
+		if tp, ok := tkg.(*TimezonePicker); ok {
+			if citiesPerPage > 0 {
+				tp.citiesPerPage = citiesPerPage
+			}
+			return tp
+		}
+		return tkg
+	}
+}
+
+// ChangeTimezonePickerShowUTCOffsets enables the UTC offset quick mode.
+func ChangeTimezonePickerShowUTCOffsets(showUTCOffsets bool) func(TimezoneKeyboardGenerator) TimezoneKeyboardGenerator {
+	return func(tkg TimezoneKeyboardGenerator) TimezoneKeyboardGenerator {
+		if tp, ok := tkg.(*TimezonePicker); ok {
+			tp.showUTCOffsets = showUTCOffsets
+			return tp
+		}
+		return tkg
+	}
+}
+
+// ChangeTimezonePickerBackButtonName ...
+func ChangeTimezonePickerBackButtonName(backButtonName string) func(TimezoneKeyboardGenerator) TimezoneKeyboardGenerator {
+	return func(tkg TimezoneKeyboardGenerator) TimezoneKeyboardGenerator {
+		if tp, ok := tkg.(*TimezonePicker); ok {
+			tp.backButtonName = backButtonName
+			return tp
+		}
+		return tkg
+	}
+}
+
+// ChangeTimezonePickerUTCOffsetsButtonName ...
+func ChangeTimezonePickerUTCOffsetsButtonName(utcOffsetsButtonName string) func(TimezoneKeyboardGenerator) TimezoneKeyboardGenerator {
+	return func(tkg TimezoneKeyboardGenerator) TimezoneKeyboardGenerator {
+		if tp, ok := tkg.(*TimezonePicker); ok {
+			tp.utcOffsetsButtonName = utcOffsetsButtonName
+			return tp
+		}
+		return tkg
+	}
+}
+
+// ChangeTimezonePickerNumeralSystem changes digits of the UTC offsets labels.
+func ChangeTimezonePickerNumeralSystem(
+	numeralSystem day_button_former.NumeralSystem,
+) func(TimezoneKeyboardGenerator) TimezoneKeyboardGenerator {
+	return func(tkg TimezoneKeyboardGenerator) TimezoneKeyboardGenerator {
+		if tp, ok := tkg.(*TimezonePicker); ok {
+			tp.numeralSystem = numeralSystem
+			return tp
+		}
+		return tkg
+	}
+}
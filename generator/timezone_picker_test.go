@@ -0,0 +1,290 @@
+package generator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thevan4/telegram-calendar/day_button_former"
+	"github.com/thevan4/telegram-calendar/models"
+)
+
+func TestGenerateTimezoneKeyboard(t *testing.T) {
+	t.Parallel()
+
+	tp := NewTimezonePicker(
+		ChangeTimezonePickerRegions([]TimezoneRegion{
+			{Name: "Europe", Zones: []string{"Europe/Berlin", "Europe/London", "Europe/Moscow"}},
+			{Name: "America", Zones: []string{"America/New_York", "America/Argentina/Buenos_Aires"}},
+			{Name: "Broken", Zones: []string{"Nowhere/Atlantis"}},
+		}),
+		ChangeTimezonePickerCitiesPerPage(2),
+	)
+
+	regionsKeyboard := models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: "Europe", CallbackData: "timezone/rgn_0.0"},
+				{Text: "America", CallbackData: "timezone/rgn_1.0"},
+			},
+			{
+				{Text: "Broken", CallbackData: "timezone/rgn_2.0"},
+			},
+			{
+				{Text: "UTC±", CallbackData: "timezone/ofs_0.0"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		callbackPayload  string
+		wantKeyboard     models.InlineKeyboardMarkup
+		wantLocationName string
+		wantUnknownZone  string
+	}{
+		{
+			name:            "first keyboard",
+			callbackPayload: "",
+			wantKeyboard:    regionsKeyboard,
+		},
+		{
+			name:            "back to regions",
+			callbackPayload: "timezone/rgs_0.0",
+			wantKeyboard:    regionsKeyboard,
+		},
+		{
+			name:            "region first page",
+			callbackPayload: "timezone/rgn_0.0",
+			wantKeyboard: models.InlineKeyboardMarkup{
+				InlineKeyboard: [][]models.InlineKeyboardButton{
+					{
+						{Text: "Berlin", CallbackData: "timezone/sez_0.0"},
+						{Text: "London", CallbackData: "timezone/sez_0.1"},
+					},
+					{
+						{Text: " ", CallbackData: "timezone/sdn_0.0"},
+						{Text: "↩", CallbackData: "timezone/rgs_0.0"},
+						{Text: "»", CallbackData: "timezone/rgn_0.1"},
+					},
+				},
+			},
+		},
+		{
+			name:            "region last page",
+			callbackPayload: "timezone/rgn_0.1",
+			wantKeyboard: models.InlineKeyboardMarkup{
+				InlineKeyboard: [][]models.InlineKeyboardButton{
+					{
+						{Text: "Moscow", CallbackData: "timezone/sez_0.2"},
+					},
+					{
+						{Text: "«", CallbackData: "timezone/rgn_0.0"},
+						{Text: "↩", CallbackData: "timezone/rgs_0.0"},
+						{Text: " ", CallbackData: "timezone/sdn_0.0"},
+					},
+				},
+			},
+		},
+		{
+			name:            "region page out of range",
+			callbackPayload: "timezone/rgn_1.9",
+			wantKeyboard: models.InlineKeyboardMarkup{
+				InlineKeyboard: [][]models.InlineKeyboardButton{
+					{
+						{Text: "New York", CallbackData: "timezone/sez_1.0"},
+						{Text: "Buenos Aires", CallbackData: "timezone/sez_1.1"},
+					},
+					{
+						{Text: " ", CallbackData: "timezone/sdn_0.0"},
+						{Text: "↩", CallbackData: "timezone/rgs_0.0"},
+						{Text: " ", CallbackData: "timezone/sdn_0.0"},
+					},
+				},
+			},
+		},
+		{
+			name:            "unknown region",
+			callbackPayload: "timezone/rgn_7.0",
+			wantKeyboard:    regionsKeyboard,
+		},
+		{
+			name:             "select city",
+			callbackPayload:  "timezone/sez_1.1",
+			wantLocationName: "America/Argentina/Buenos_Aires",
+		},
+		{
+			name:            "select unknown city",
+			callbackPayload: "timezone/sez_1.5",
+			wantKeyboard:    regionsKeyboard,
+		},
+		{
+			name:            "select broken zone",
+			callbackPayload: "timezone/sez_2.0",
+			wantKeyboard:    regionsKeyboard,
+			wantUnknownZone: "Nowhere/Atlantis",
+		},
+		{
+			name:             "select utc offset",
+			callbackPayload:  "timezone/seo_1050.0",
+			wantLocationName: "UTC+05:30",
+		},
+		{
+			name:             "select negative utc offset",
+			callbackPayload:  "timezone/seo_510.0",
+			wantLocationName: "UTC-03:30",
+		},
+		{
+			name:             "select zero utc offset",
+			callbackPayload:  "timezone/seo_720.0",
+			wantLocationName: "UTC",
+		},
+		{
+			name:            "select utc offset out of range",
+			callbackPayload: "timezone/seo_9999.0",
+			wantKeyboard:    regionsKeyboard,
+		},
+		{
+			name:            "do nothing",
+			callbackPayload: "timezone/sdn_0.0",
+			wantKeyboard:    models.InlineKeyboardMarkup{},
+		},
+		{
+			name:            "calendar payload",
+			callbackPayload: "calendar/sed_01.06.2023",
+			wantKeyboard:    regionsKeyboard,
+		},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			response := tp.GenerateTimezoneKeyboard(tt.callbackPayload)
+			if !isSlicesOfSlicesEqual(response.InlineKeyboardMarkup.InlineKeyboard, tt.wantKeyboard.InlineKeyboard) {
+				t.Errorf("unexpected keyboard: got: %v, want: %v", response.InlineKeyboardMarkup.InlineKeyboard, tt.wantKeyboard.InlineKeyboard)
+			}
+			if response.UnknownZone != tt.wantUnknownZone {
+				t.Errorf("unexpected unknown zone: got: %v, want: %v", response.UnknownZone, tt.wantUnknownZone)
+			}
+			switch {
+			case tt.wantLocationName == "" && response.SelectedLocation != nil:
+				t.Errorf("unexpected selected location: %v", response.SelectedLocation)
+			case tt.wantLocationName != "" && response.SelectedLocation == nil:
+				t.Errorf("selected location is nil, want: %v", tt.wantLocationName)
+			case tt.wantLocationName != "" && response.SelectedLocation.String() != tt.wantLocationName:
+				t.Errorf("unexpected selected location: got: %v, want: %v", response.SelectedLocation, tt.wantLocationName)
+			}
+		},
+		)
+	}
+}
+
+func TestGenerateUTCOffsets(t *testing.T) {
+	t.Parallel()
+
+	tp := NewTimezonePicker(
+		ChangeTimezonePickerNumeralSystem(day_button_former.NumeralsArabicIndic),
+		ChangeTimezonePickerBackButtonName("back"),
+	).(*TimezonePicker)
+
+	keyboard := tp.GenerateUTCOffsets().InlineKeyboard
+	lastRow := keyboard[len(keyboard)-1]
+	if len(lastRow) != 1 || lastRow[0].Text != "back" || lastRow[0].CallbackData != "timezone/rgs_0.0" {
+		t.Errorf("unexpected back row: %v", lastRow)
+	}
+
+	if keyboard[0][0].Text != "UTC-١٢" || keyboard[0][0].CallbackData != "timezone/seo_0.0" {
+		t.Errorf("unexpected first offset button: %v", keyboard[0][0])
+	}
+
+	// UTC+5:45.
+	btn := keyboard[5][3]
+	if btn.Text != "UTC+٥:٤٥" || btn.CallbackData != "timezone/seo_1065.0" {
+		t.Errorf("unexpected +5:45 offset button: %v", btn)
+	}
+
+	location := tp.GenerateTimezoneKeyboard(btn.CallbackData).SelectedLocation
+	_, offset := time.Date(2023, 6, 1, 0, 0, 0, 0, location).Zone()
+	if offset != (5*60+45)*60 {
+		t.Errorf("unexpected selected offset: got: %v, want: %v", offset, (5*60+45)*60)
+	}
+}
+
+func TestTimezonePickerWithoutUTCOffsets(t *testing.T) {
+	t.Parallel()
+
+	tp := NewTimezonePicker(ChangeTimezonePickerShowUTCOffsets(false))
+
+	keyboard := tp.GenerateTimezoneKeyboard("").InlineKeyboardMarkup.InlineKeyboard
+	lastRow := keyboard[len(keyboard)-1]
+	if lastRow[0].CallbackData == "timezone/ofs_0.0" {
+		t.Errorf("utc offsets button is shown: %v", lastRow)
+	}
+
+	if location := tp.GenerateTimezoneKeyboard("timezone/seo_1050.0").SelectedLocation; location != nil {
+		t.Errorf("utc offset selected while quick mode is off: %v", location)
+	}
+}
+
+func TestDefaultTimezoneRegionsAreLoadable(t *testing.T) {
+	t.Parallel()
+
+	for _, region := range timezoneRegionsDefault {
+		for _, zone := range region.Zones {
+			if _, err := time.LoadLocation(zone); err != nil {
+				t.Errorf("at time.LoadLocation for %v error: %v", zone, err)
+			}
+		}
+	}
+}
+
+func TestLoadableTimezoneRegions(t *testing.T) {
+	t.Parallel()
+
+	got := loadableTimezoneRegions([]TimezoneRegion{
+		{Name: "Europe", Zones: []string{"Europe/Berlin", "Nowhere/Atlantis", "Europe/London"}},
+		{Name: "Broken", Zones: []string{"Nowhere/Atlantis"}},
+	})
+	if len(got) != 1 || got[0].Name != "Europe" || len(got[0].Zones) != 2 || got[0].Zones[1] != "Europe/London" {
+		t.Errorf("unexpected loadable regions: %v", got)
+	}
+}
+
+func TestTimezonePickerApplyNewOptionsIsCopyOnWrite(t *testing.T) {
+	t.Parallel()
+
+	regions := []TimezoneRegion{{Name: "Europe", Zones: []string{"Europe/Berlin", "Europe/London"}}}
+	picker := NewTimezonePicker()
+	changed := picker.ApplyNewOptions(ChangeTimezonePickerCitiesPerPage(1), ChangeTimezonePickerRegions(regions))
+	// Changes of the caller's regions after the option do not change the picker.
+	regions[0].Zones[0] = "Asia/Tokyo"
+
+	if tp := picker.(*TimezonePicker); tp.citiesPerPage != timezoneCitiesPerPageDefault || len(tp.regions) == 1 {
+		t.Errorf("receiver is changed by the options: %+v", tp)
+	}
+	if tp := changed.(*TimezonePicker); tp.citiesPerPage != 1 || tp.regions[0].Zones[0] != "Europe/Berlin" {
+		t.Errorf("unexpected picker with the options: %+v", tp)
+	}
+}
+
+func TestTimezoneCallbackDataLen(t *testing.T) {
+	t.Parallel()
+
+	const maxCallbackDataLen = 64
+
+	tp := NewTimezonePicker().(*TimezonePicker)
+	keyboards := []models.InlineKeyboardMarkup{tp.GenerateRegions(), tp.GenerateUTCOffsets()}
+	for i := range tp.regions {
+		keyboards = append(keyboards, tp.GenerateRegionCities(i, 0))
+	}
+
+	for _, keyboard := range keyboards {
+		for _, row := range keyboard.InlineKeyboard {
+			for _, btn := range row {
+				if len(btn.CallbackData) > maxCallbackDataLen {
+					t.Errorf("too long callback data %v", btn.CallbackData)
+				}
+			}
+		}
+	}
+}
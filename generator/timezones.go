@@ -0,0 +1,106 @@
+package generator
+
+import "time"
+
+// TimezoneRegion is a group of IANA timezones shown on one button of the timezone picker.
+type TimezoneRegion struct {
+	Name  string
+	Zones []string
+}
+
+// The most used zones only, the full IANA list is too long for a keyboard.
+// The zones that the host tzdata has not (Europe/Kyiv is missing from the older ones) are dropped.
+var timezoneRegionsDefault = loadableTimezoneRegions([]TimezoneRegion{ //nolint:gochecknoglobals
+	{
+		Name: "Africa",
+		Zones: []string{
+			"Africa/Abidjan", "Africa/Accra", "Africa/Addis_Ababa", "Africa/Algiers", "Africa/Cairo",
+			"Africa/Casablanca", "Africa/Dakar", "Africa/Dar_es_Salaam", "Africa/Johannesburg", "Africa/Khartoum",
+			"Africa/Kinshasa", "Africa/Lagos", "Africa/Luanda", "Africa/Maputo", "Africa/Nairobi",
+			"Africa/Tripoli", "Africa/Tunis", "Africa/Windhoek",
+		},
+	},
+	{
+		Name: "America",
+		Zones: []string{
+			"America/Anchorage", "America/Argentina/Buenos_Aires", "America/Bogota", "America/Caracas",
+			"America/Chicago", "America/Denver", "America/Edmonton", "America/Guatemala", "America/Halifax",
+			"America/Havana", "America/La_Paz", "America/Lima", "America/Los_Angeles", "America/Mexico_City",
+			"America/Montevideo", "America/New_York", "America/Panama", "America/Phoenix", "America/Santiago",
+			"America/Santo_Domingo", "America/Sao_Paulo", "America/St_Johns", "America/Toronto",
+			"America/Vancouver", "America/Winnipeg",
+		},
+	},
+	{
+		Name: "Asia",
+		Zones: []string{
+			"Asia/Almaty", "Asia/Baghdad", "Asia/Baku", "Asia/Bangkok", "Asia/Colombo", "Asia/Dhaka",
+			"Asia/Dubai", "Asia/Ho_Chi_Minh", "Asia/Hong_Kong", "Asia/Irkutsk", "Asia/Jakarta", "Asia/Jerusalem",
+			"Asia/Kabul", "Asia/Karachi", "Asia/Kathmandu", "Asia/Kolkata", "Asia/Krasnoyarsk", "Asia/Kuala_Lumpur",
+			"Asia/Magadan", "Asia/Manila", "Asia/Novosibirsk", "Asia/Omsk", "Asia/Riyadh", "Asia/Seoul",
+			"Asia/Shanghai", "Asia/Singapore", "Asia/Taipei", "Asia/Tashkent", "Asia/Tbilisi", "Asia/Tehran",
+			"Asia/Tokyo", "Asia/Vladivostok", "Asia/Yakutsk", "Asia/Yekaterinburg", "Asia/Yerevan",
+		},
+	},
+	{
+		Name: "Atlantic",
+		Zones: []string{
+			"Atlantic/Azores", "Atlantic/Bermuda", "Atlantic/Canary", "Atlantic/Cape_Verde", "Atlantic/Reykjavik",
+		},
+	},
+	{
+		Name: "Australia",
+		Zones: []string{
+			"Australia/Adelaide", "Australia/Brisbane", "Australia/Darwin", "Australia/Hobart",
+			"Australia/Melbourne", "Australia/Perth", "Australia/Sydney",
+		},
+	},
+	{
+		Name: "Europe",
+		Zones: []string{
+			"Europe/Amsterdam", "Europe/Athens", "Europe/Belgrade", "Europe/Berlin", "Europe/Brussels",
+			"Europe/Bucharest", "Europe/Budapest", "Europe/Dublin", "Europe/Helsinki", "Europe/Istanbul",
+			"Europe/Kaliningrad", "Europe/Kyiv", "Europe/Lisbon", "Europe/London", "Europe/Madrid", "Europe/Minsk",
+			"Europe/Moscow", "Europe/Oslo", "Europe/Paris", "Europe/Prague", "Europe/Riga", "Europe/Rome",
+			"Europe/Samara", "Europe/Sofia", "Europe/Stockholm", "Europe/Vienna", "Europe/Vilnius",
+			"Europe/Warsaw", "Europe/Zurich",
+		},
+	},
+	{
+		Name: "Indian",
+		Zones: []string{
+			"Indian/Maldives", "Indian/Mauritius", "Indian/Reunion",
+		},
+	},
+	{
+		Name: "Pacific",
+		Zones: []string{
+			"Pacific/Auckland", "Pacific/Fiji", "Pacific/Guam", "Pacific/Honolulu", "Pacific/Port_Moresby",
+			"Pacific/Tongatapu",
+		},
+	},
+})
+
+// Offsets in minutes that are used somewhere in the world.
+var utcOffsetsMinutesDefault = []int{ //nolint:gochecknoglobals
+	-720, -660, -600, -570, -540, -480, -420, -360, -300, -240, -210, -180, -120, -60,
+	0, 60, 120, 180, 210, 240, 270, 300, 330, 345, 360, 390, 420, 480, 525, 540, 570, 600, 630, 660,
+	720, 765, 780, 840,
+}
+
+// The regions with the zones that time.LoadLocation loads, the regions without them are dropped.
+func loadableTimezoneRegions(regions []TimezoneRegion) []TimezoneRegion {
+	loadable := make([]TimezoneRegion, 0, len(regions))
+	for _, region := range regions {
+		zones := make([]string, 0, len(region.Zones))
+		for _, zone := range region.Zones {
+			if _, err := time.LoadLocation(zone); err == nil {
+				zones = append(zones, zone)
+			}
+		}
+		if len(zones) > 0 {
+			loadable = append(loadable, TimezoneRegion{Name: region.Name, Zones: zones})
+		}
+	}
+	return loadable
+}
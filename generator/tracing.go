@@ -0,0 +1,67 @@
+package generator
+
+import (
+	"context"
+
+	"github.com/thevan4/telegram-calendar/models"
+	"github.com/thevan4/telegram-calendar/payload_former"
+	"github.com/thevan4/telegram-calendar/tracing"
+)
+
+// Span names of the traced render (see WithContext). The render span is the parent of the others.
+const (
+	SpanRender = "calendar.render"
+	// Payload decoding, once per render.
+	SpanDecode = "calendar.decode"
+	// Unselectable rules and the load provider of the day, once per day button.
+	SpanAvailability = "calendar.availability"
+	// The event source lookup.
+	SpanEvents = "calendar.events"
+)
+
+// Span attributes.
+const (
+	AttributePayload      = "calendar.payload"
+	AttributeAction       = "calendar.action"
+	AttributeDate         = "calendar.date"
+	AttributeMonth        = "calendar.month"
+	AttributeUnselectable = "calendar.unselectable"
+	AttributeEventsCount  = "calendar.events_count"
+	// The payload encodings (one per button) of the render, at the render span.
+	AttributeEncodesCount = "calendar.encodes_count"
+)
+
+// Starts the render span and makes it the parent of the render lookups, the returned func ends it.
+// k is the render copy (see withRenderSettings), so it is changed in place.
+func (k *KeyboardFormer) startRenderSpan(callbackPayload string) func() {
+	ctx, span := tracing.Start(k.renderCtx, SpanRender)
+	span.SetAttribute(AttributePayload, callbackPayload)
+	k.renderCtx = ctx
+	encoderDecoder := &tracedEncoderDecoder{next: k.payloadEncoderDecoder, ctx: ctx}
+	k.payloadEncoderDecoder = encoderDecoder
+	return func() {
+		span.SetAttribute(AttributeEncodesCount, encoderDecoder.encodes)
+		span.End()
+	}
+}
+
+// Traces the payload encoder calls of one render: the decoding has its span,
+// the encodings (one per button) are counted for the render span.
+type tracedEncoderDecoder struct {
+	next    payload_former.PayloadEncoderDecoder
+	ctx     context.Context
+	encodes int
+}
+
+func (t *tracedEncoderDecoder) Encoding(action string, day, month, year int) string {
+	t.encodes++
+	return t.next.Encoding(action, day, month, year)
+}
+
+func (t *tracedEncoderDecoder) Decoding(input string) models.PayloadData {
+	_, span := tracing.Start(t.ctx, SpanDecode)
+	defer span.End()
+	payloadData := t.next.Decoding(input)
+	span.SetAttribute(AttributeAction, payloadData.Action)
+	return payloadData
+}
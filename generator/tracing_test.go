@@ -0,0 +1,154 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/thevan4/telegram-calendar/day_button_former"
+	"github.com/thevan4/telegram-calendar/models"
+	"github.com/thevan4/telegram-calendar/tracing"
+)
+
+func TestGenerateCalendarKeyboardTracing(t *testing.T) {
+	t.Parallel()
+
+	ct62023 := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	source := NewMemoryEventSource(models.Event{ID: "1", Title: "Standup", Start: time.Date(2023, 6, 5, 9, 0, 0, 0, time.UTC)})
+	failingLoadProvider := day_button_former.LoadProviderContextFunc(func(_ context.Context, date time.Time) (float64, bool, error) {
+		if date.Day() == 10 {
+			return 0, false, errors.New("lookup failed")
+		}
+		return 0, true, nil
+	})
+
+	tests := []struct {
+		name            string
+		options         []func(KeyboardGenerator) KeyboardGenerator
+		callbackPayload string
+		// Spans count by name, without the parent span of the test.
+		wantSpans   map[string]int
+		wantEncodes int
+		wantErrs    int
+	}{
+		{
+			name:            "month",
+			options:         []func(KeyboardGenerator) KeyboardGenerator{ChangeEventSource(source)},
+			callbackPayload: "calendar/nem_00.05.2023",
+			wantSpans:       map[string]int{SpanRender: 1, SpanDecode: 1, SpanEvents: 1, SpanAvailability: 30},
+			// 30 days; 7 header, 7 days names and 30 + 5 blank days buttons.
+			wantEncodes: 49,
+		},
+		{
+			name:            "selection",
+			callbackPayload: "calendar/sed_12.06.2023",
+			wantSpans:       map[string]int{SpanRender: 1, SpanDecode: 1},
+		},
+		{
+			name:            "failing event source",
+			options:         []func(KeyboardGenerator) KeyboardGenerator{ChangeEventSource(failingEventSource{})},
+			callbackPayload: "calendar/evd_05.06.2023",
+			wantSpans:       map[string]int{SpanRender: 1, SpanDecode: 1, SpanEvents: 1},
+			wantErrs:        1,
+		},
+		{
+			name: "failing load provider",
+			options: []func(KeyboardGenerator) KeyboardGenerator{
+				ApplyNewOptionsForButtonsTextWrapper(day_button_former.ChangeLoadProvider(failingLoadProvider)),
+			},
+			callbackPayload: "calendar/nem_00.05.2023",
+			wantSpans:       map[string]int{SpanRender: 1, SpanDecode: 1, SpanAvailability: 30},
+			wantEncodes:     49,
+			wantErrs:        1,
+		},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			recorder := tracing.NewRecorder()
+			ctx, parent := recorder.Start(context.Background(), "bot")
+			kg := NewKeyboardFormer(tt.options...)
+
+			GenerateCalendarKeyboardWithOptions(kg, tt.callbackPayload, ct62023,
+				WithContext(tracing.ContextWithTracer(ctx, recorder)))
+			parent.End()
+
+			spans := recorder.Spans()
+			render := spans[1]
+			if render.Name != SpanRender || render.ParentID != spans[0].ID ||
+				render.Attributes[AttributePayload] != tt.callbackPayload || render.Attributes[AttributeEncodesCount] != tt.wantEncodes {
+				t.Errorf("unexpected render span: %+v", render)
+				return
+			}
+
+			gotSpans := make(map[string]int)
+			gotErrs := 0
+			for _, span := range spans[1:] {
+				gotSpans[span.Name]++
+				gotErrs += len(span.Errors)
+				if span.End.IsZero() {
+					t.Errorf("span %v is not ended", span.Name)
+				}
+				if span.Name != SpanRender && span.ParentID != render.ID {
+					t.Errorf("span %v is not a child of the render: %v", span.Name, span.ParentID)
+				}
+			}
+			if !reflect.DeepEqual(gotSpans, tt.wantSpans) {
+				t.Errorf("unexpected spans: got %v, want %v", gotSpans, tt.wantSpans)
+			}
+			if gotErrs != tt.wantErrs {
+				t.Errorf("unexpected errors count: got %v, want %v", gotErrs, tt.wantErrs)
+			}
+		},
+		)
+	}
+}
+
+func TestGenerateCalendarKeyboardTracingAttributes(t *testing.T) {
+	t.Parallel()
+
+	recorder := tracing.NewRecorder()
+	kg := NewKeyboardFormer(ApplyNewOptionsForButtonsTextWrapper(
+		day_button_former.ChangeUnselectableDaysBeforeDate(time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC)),
+	))
+
+	GenerateCalendarKeyboardWithOptions(kg, "calendar/shs_00.06.2023", time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+		WithContext(tracing.ContextWithTracer(context.Background(), recorder)))
+
+	var decode, firstDay, secondDay tracing.RecordedSpan
+	for _, span := range recorder.Spans() {
+		switch {
+		case span.Name == SpanDecode:
+			decode = span
+		case span.Name == SpanAvailability && span.Attributes[AttributeDate] == "2023-06-01":
+			firstDay = span
+		case span.Name == SpanAvailability && span.Attributes[AttributeDate] == "2023-06-02":
+			secondDay = span
+		}
+	}
+	if decode.Attributes[AttributeAction] != showSelectedAction {
+		t.Errorf("unexpected decode span: %+v", decode)
+	}
+	if firstDay.Attributes[AttributeUnselectable] != true || secondDay.Attributes[AttributeUnselectable] != false {
+		t.Errorf("unexpected availability spans: %+v, %+v", firstDay, secondDay)
+	}
+}
+
+func TestGenerateCalendarKeyboardWithNoopTracer(t *testing.T) {
+	t.Parallel()
+
+	kg := NewKeyboardFormer()
+
+	// The context without a tracer is traced with tracing.NoopTracer.
+	withoutContext := kg.GenerateCalendarKeyboard("", time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC))
+	withNoopContext := GenerateCalendarKeyboardWithOptions(kg, "", time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+		WithContext(context.Background()))
+
+	if !reflect.DeepEqual(withoutContext, withNoopContext) {
+		t.Errorf("traced render differs: %+v, %+v", withoutContext, withNoopContext)
+	}
+}
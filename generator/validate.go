@@ -0,0 +1,143 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/thevan4/telegram-calendar/models"
+)
+
+// Config validation errors, wrapped by ConfigError.
+var (
+	ErrNegativeValue          = errors.New("must not be negative")
+	ErrTooManyYears           = fmt.Errorf("years back and forward together must not be more than %d", maxSumYearsForChoose)
+	ErrEmptyName              = errors.New("must not be empty")
+	ErrUnknownMode            = errors.New("unknown value")
+	ErrNilEncoderDecoder      = errors.New("payload encoder decoder is nil")
+	ErrWrongUnselectableRange = errors.New("unselectable days after time is before the before time")
+	ErrLoadOutOfRange         = errors.New("must be from 0 to 1")
+)
+
+// ConfigError points at the FlatConfig field with the wrong value, like "DaysNames[3]".
+type ConfigError struct {
+	Field string
+	Err   error
+}
+
+func (e *ConfigError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// The rows below go after the calendar, so none of their buttons is the first one of the keyboard:
+// pay and callback game buttons are allowed at ExtraRowsAbove[0][0] only.
+func validateExtraRowsBelow(rows [][]models.InlineKeyboardButton) error {
+	if err := (models.InlineKeyboardMarkup{InlineKeyboard: rows}).Validate(); err != nil {
+		return err
+	}
+	for i, row := range rows {
+		for j, button := range row {
+			if button.Pay || button.CallbackGame != nil {
+				return fmt.Errorf("row %d button %d: %w", i, j, models.ErrButtonMustBeFirst)
+			}
+		}
+	}
+	return nil
+}
+
+// Validate checks the settings the options accept silently, but which break the keyboard.
+// All problems are joined into one error, each is *ConfigError; nil means the config is fine.
+//
+//nolint:cyclop // flat list of checks.
+func (c FlatConfig) Validate() error {
+	var errs []error
+	addErr := func(field string, err error) {
+		errs = append(errs, &ConfigError{Field: field, Err: err})
+	}
+
+	if c.YearsBackForChoose < 0 {
+		addErr("YearsBackForChoose", ErrNegativeValue)
+	}
+	if c.YearsForwardForChoose < 0 {
+		addErr("YearsForwardForChoose", ErrNegativeValue)
+	}
+	if c.SumYearsForChoose > maxSumYearsForChoose {
+		addErr("SumYearsForChoose", ErrTooManyYears)
+	}
+
+	if !c.HideDaysNames {
+		for i, name := range c.DaysNames {
+			if name == "" {
+				addErr("DaysNames["+strconv.Itoa(i)+"]", ErrEmptyName)
+			}
+		}
+	}
+	for i, name := range c.MonthNames {
+		if name == "" {
+			addErr("MonthNames["+strconv.Itoa(i)+"]", ErrEmptyName)
+		}
+	}
+
+	if c.HeaderLayout < HeaderLayoutFull || c.HeaderLayout > HeaderLayoutCompact {
+		addErr("HeaderLayout", ErrUnknownMode)
+	}
+	names := []struct {
+		field, value string
+		used         bool
+	}{
+		{"HomeButtonForBeauty", c.HomeButtonForBeauty, !c.HideHomeButton && c.HeaderLayout != HeaderLayoutCompact},
+		{"PrevMonthName", c.PrevMonthName, true},
+		{"NextMonthName", c.NextMonthName, true},
+		{"PrevYearName", c.PrevYearName, c.HeaderLayout != HeaderLayoutCompact},
+		{"NextYearName", c.NextYearName, c.HeaderLayout != HeaderLayoutCompact},
+	}
+	for _, name := range names {
+		if name.used && name.value == "" {
+			addErr(name.field, ErrEmptyName)
+		}
+	}
+
+	if c.AdjacentDaysMode < AdjacentDaysHidden || c.AdjacentDaysMode > AdjacentDaysNavigate {
+		addErr("AdjacentDaysMode", ErrUnknownMode)
+	}
+	for i, button := range c.FooterButtons {
+		if button.Action < FooterActionToday || button.Action > FooterActionConfirm {
+			addErr("FooterButtons["+strconv.Itoa(i)+"].Action", ErrUnknownMode)
+		}
+	}
+	if c.PayloadEncoderDecoder == nil {
+		addErr("PayloadEncoderDecoder", ErrNilEncoderDecoder)
+	}
+
+	if len(c.ExtraRowsAbove) != 0 {
+		if err := (models.InlineKeyboardMarkup{InlineKeyboard: c.ExtraRowsAbove}).Validate(); err != nil {
+			addErr("ExtraRowsAbove", err)
+		}
+	}
+	if len(c.ExtraRowsBelow) != 0 {
+		if err := validateExtraRowsBelow(c.ExtraRowsBelow); err != nil {
+			addErr("ExtraRowsBelow", err)
+		}
+	}
+
+	if !c.UnselectableDaysBeforeTime.IsZero() && !c.UnselectableDaysAfterTime.IsZero() &&
+		c.UnselectableDaysAfterTime.Before(c.UnselectableDaysBeforeTime) {
+		addErr("UnselectableDaysAfterTime", ErrWrongUnselectableRange)
+	}
+
+	for i, level := range c.LoadLevels {
+		if level.From < 0 || level.From > 1 || math.IsNaN(level.From) {
+			addErr("LoadLevels["+strconv.Itoa(i)+"].From", ErrLoadOutOfRange)
+		}
+	}
+	if c.FullLoadThreshold < 0 || math.IsNaN(c.FullLoadThreshold) {
+		addErr("FullLoadThreshold", ErrNegativeValue)
+	}
+
+	return errors.Join(errs...)
+}
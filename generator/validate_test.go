@@ -0,0 +1,210 @@
+package generator
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/thevan4/telegram-calendar/day_button_former"
+	"github.com/thevan4/telegram-calendar/models"
+)
+
+func TestFlatConfigValidate(t *testing.T) { //nolint:funlen // table.
+	t.Parallel()
+
+	type wantError struct {
+		field string
+		err   error
+	}
+	tests := []struct {
+		name    string
+		options []func(KeyboardGenerator) KeyboardGenerator
+		want    []wantError
+	}{
+		{
+			name: "default",
+		},
+		{
+			name: "valid custom",
+			options: []func(KeyboardGenerator) KeyboardGenerator{
+				ChangeYearsBackForChoose(3),
+				ChangeYearsForwardForChoose(3),
+				ChangeHeaderLayout(HeaderLayoutCompact),
+				ChangeHomeButtonForBeauty(""),
+				ChangePrevYearName(""),
+				ChangeHideDaysNames(true),
+				ChangeDaysNames([7]string{}),
+				ApplyNewOptionsForButtonsTextWrapper(day_button_former.ChangeFullLoadThreshold(2)),
+			},
+		},
+		{
+			name:    "negative years back",
+			options: []func(KeyboardGenerator) KeyboardGenerator{ChangeYearsBackForChoose(-5)},
+			want:    []wantError{{"YearsBackForChoose", ErrNegativeValue}},
+		},
+		{
+			name:    "negative years forward",
+			options: []func(KeyboardGenerator) KeyboardGenerator{ChangeYearsForwardForChoose(-1)},
+			want:    []wantError{{"YearsForwardForChoose", ErrNegativeValue}},
+		},
+		{
+			name:    "too many years forward",
+			options: []func(KeyboardGenerator) KeyboardGenerator{ChangeYearsForwardForChoose(100)},
+			want:    []wantError{{"SumYearsForChoose", ErrTooManyYears}},
+		},
+		{
+			name:    "too many years together",
+			options: []func(KeyboardGenerator) KeyboardGenerator{ChangeYearsBackForChoose(4), ChangeYearsForwardForChoose(3)},
+			want:    []wantError{{"SumYearsForChoose", ErrTooManyYears}},
+		},
+		{
+			name:    "empty days names",
+			options: []func(KeyboardGenerator) KeyboardGenerator{ChangeDaysNames([7]string{"Mo", "Tu", "", "Th", "Fr", "Sa", ""})},
+			want:    []wantError{{"DaysNames[2]", ErrEmptyName}, {"DaysNames[6]", ErrEmptyName}},
+		},
+		{
+			name:    "empty month name",
+			options: []func(KeyboardGenerator) KeyboardGenerator{ChangeMonthNames([12]string{0: "Jan", 11: "Dec"})},
+			want: []wantError{
+				{"MonthNames[1]", ErrEmptyName}, {"MonthNames[2]", ErrEmptyName}, {"MonthNames[3]", ErrEmptyName},
+				{"MonthNames[4]", ErrEmptyName}, {"MonthNames[5]", ErrEmptyName}, {"MonthNames[6]", ErrEmptyName},
+				{"MonthNames[7]", ErrEmptyName}, {"MonthNames[8]", ErrEmptyName}, {"MonthNames[9]", ErrEmptyName},
+				{"MonthNames[10]", ErrEmptyName},
+			},
+		},
+		{
+			name:    "empty home button",
+			options: []func(KeyboardGenerator) KeyboardGenerator{ChangeHomeButtonForBeauty("")},
+			want:    []wantError{{"HomeButtonForBeauty", ErrEmptyName}},
+		},
+		{
+			name: "empty navigation names",
+			options: []func(KeyboardGenerator) KeyboardGenerator{
+				ChangePrevMonthName(""), ChangeNextMonthName(""), ChangePrevYearName(""), ChangeNextYearName(""),
+			},
+			want: []wantError{
+				{"PrevMonthName", ErrEmptyName}, {"NextMonthName", ErrEmptyName},
+				{"PrevYearName", ErrEmptyName}, {"NextYearName", ErrEmptyName},
+			},
+		},
+		{
+			name:    "unknown header layout",
+			options: []func(KeyboardGenerator) KeyboardGenerator{ChangeHeaderLayout(HeaderLayout(10))},
+			want:    []wantError{{"HeaderLayout", ErrUnknownMode}},
+		},
+		{
+			name:    "unknown adjacent days mode",
+			options: []func(KeyboardGenerator) KeyboardGenerator{ChangeAdjacentDaysMode(AdjacentDaysMode(-1))},
+			want:    []wantError{{"AdjacentDaysMode", ErrUnknownMode}},
+		},
+		{
+			name: "unknown footer action",
+			options: []func(KeyboardGenerator) KeyboardGenerator{
+				ChangeFooterButtons(FooterButton{Action: FooterActionToday}, FooterButton{}),
+			},
+			want: []wantError{{"FooterButtons[1].Action", ErrUnknownMode}},
+		},
+		{
+			name:    "nil payload encoder decoder",
+			options: []func(KeyboardGenerator) KeyboardGenerator{ChangePayloadEncoderDecoder(nil)},
+			want:    []wantError{{"PayloadEncoderDecoder", ErrNilEncoderDecoder}},
+		},
+		{
+			name: "wrong extra rows",
+			options: []func(KeyboardGenerator) KeyboardGenerator{
+				ChangeExtraRowsAbove([][]models.InlineKeyboardButton{{models.NewInlineKeyboardButton("", "data")}}),
+				ChangeExtraRowsBelow([][]models.InlineKeyboardButton{{}}),
+			},
+			want: []wantError{{"ExtraRowsAbove", models.ErrEmptyButtonText}, {"ExtraRowsBelow", models.ErrEmptyInlineKeyboardRow}},
+		},
+		{
+			name: "pay button is first of the rows below",
+			options: []func(KeyboardGenerator) KeyboardGenerator{
+				ChangeExtraRowsBelow([][]models.InlineKeyboardButton{{{Text: "Pay", Pay: true}}}),
+			},
+			want: []wantError{{"ExtraRowsBelow", models.ErrButtonMustBeFirst}},
+		},
+		{
+			name: "callback game button is first of the rows below",
+			options: []func(KeyboardGenerator) KeyboardGenerator{
+				ChangeExtraRowsAbove([][]models.InlineKeyboardButton{{{Text: "Game", CallbackGame: &models.CallbackGame{}}}}),
+				ChangeExtraRowsBelow([][]models.InlineKeyboardButton{{{Text: "Game", CallbackGame: &models.CallbackGame{}}}}),
+			},
+			want: []wantError{{"ExtraRowsBelow", models.ErrButtonMustBeFirst}},
+		},
+		{
+			name: "after time before before time",
+			options: []func(KeyboardGenerator) KeyboardGenerator{
+				ApplyNewOptionsForButtonsTextWrapper(
+					day_button_former.ChangeUnselectableDaysBeforeDate(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+					day_button_former.ChangeUnselectableDaysAfterDate(time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)),
+				),
+			},
+			want: []wantError{{"UnselectableDaysAfterTime", ErrWrongUnselectableRange}},
+		},
+		{
+			name: "wrong load settings",
+			options: []func(KeyboardGenerator) KeyboardGenerator{
+				ApplyNewOptionsForButtonsTextWrapper(
+					day_button_former.ChangeLoadLevels(
+						day_button_former.LoadLevel{From: -0.5, Marker: "?"},
+						day_button_former.LoadLevel{From: 0.5, Marker: "!"},
+						day_button_former.LoadLevel{From: math.NaN(), Marker: "!"},
+					),
+					day_button_former.ChangeFullLoadThreshold(-1),
+				),
+			},
+			want: []wantError{
+				{"LoadLevels[0].From", ErrLoadOutOfRange},
+				{"LoadLevels[2].From", ErrLoadOutOfRange},
+				{"FullLoadThreshold", ErrNegativeValue},
+			},
+		},
+		{
+			name: "several problems",
+			options: []func(KeyboardGenerator) KeyboardGenerator{
+				ChangeYearsBackForChoose(-5),
+				ChangeYearsForwardForChoose(100),
+				ChangeNextMonthName(""),
+			},
+			want: []wantError{
+				{"YearsBackForChoose", ErrNegativeValue},
+				{"SumYearsForChoose", ErrTooManyYears},
+				{"NextMonthName", ErrEmptyName},
+			},
+		},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := NewKeyboardFormer(tt.options...).GetCurrentConfig().Validate()
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var joined interface{ Unwrap() []error }
+			if !errors.As(err, &joined) {
+				t.Errorf("expected joined errors, got: %v", err)
+				return
+			}
+			errs := joined.Unwrap()
+			if len(errs) != len(tt.want) {
+				t.Errorf("unexpected errors count: got: %v, want: %v", err, tt.want)
+				return
+			}
+			for i, want := range tt.want {
+				var configErr *ConfigError
+				if !errors.As(errs[i], &configErr) || configErr.Field != want.field || !errors.Is(errs[i], want.err) {
+					t.Errorf("unexpected error %d: got: %v, want: %v: %v", i, errs[i], want.field, want.err)
+				}
+			}
+		},
+		)
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/thevan4/telegram-calendar
 
-go 1.19
+go 1.21
@@ -0,0 +1,94 @@
+package manager
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thevan4/telegram-calendar/day_button_former"
+	"github.com/thevan4/telegram-calendar/generator"
+)
+
+// Meant for go test -race: renders, reconfigurations and config reads at once.
+func TestConcurrentRendersAndReconfigurations(t *testing.T) {
+	t.Parallel()
+
+	const (
+		renderers   = 8
+		reconfigers = 4
+		iterations  = 200
+	)
+
+	m := NewManager()
+	currentTime := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+	payloads := []string{"", "calendar/prm_00.06.2023", "calendar/nem_00.06.2023", "calendar/sey_00.06.2023", "calendar/sed_20.06.2023"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < renderers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < iterations; j++ {
+				response := m.GenerateCalendarKeyboard(payloads[(i+j)%len(payloads)], currentTime)
+				if len(response.InlineKeyboardMarkup.InlineKeyboard) == 0 && response.SelectedDay.IsZero() {
+					t.Errorf("empty response for %v", payloads[(i+j)%len(payloads)])
+					return
+				}
+			}
+		}(i)
+	}
+
+	for i := 0; i < reconfigers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < iterations; j++ {
+				day := time.Date(2023, 6, 1+(i+j)%28, 0, 0, 0, 0, time.UTC)
+				m.ApplyNewOptions(
+					generator.ChangeYearsBackForChoose(j%3),
+					generator.ChangeHomeButtonForBeauty(fmt.Sprint(i)),
+					generator.ChangeFooterButtons(generator.FooterButton{Action: generator.FooterActionToday}),
+					generator.ApplyNewOptionsForButtonsTextWrapper(
+						day_button_former.ChangeUnselectableDays(map[time.Time]struct{}{day: {}}),
+						day_button_former.ChangePostfixForPickDay(fmt.Sprint(j)),
+					),
+				)
+
+				// The returned config is a copy, changing it must not race with the renders.
+				config := m.GetCurrentConfig()
+				config.UnselectableDays[day.AddDate(0, 1, 0)] = struct{}{}
+				config.FooterButtons[0].Text = "changed"
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	config := m.GetCurrentConfig()
+	if len(config.UnselectableDays) != 1 || config.FooterButtons[0].Text != "" {
+		t.Errorf("manager config is changed through the returned config: %v, %v", config.UnselectableDays, config.FooterButtons)
+	}
+}
+
+func TestApplyNewOptionsKeepsPreviousGenerator(t *testing.T) {
+	t.Parallel()
+
+	m := NewManager(generator.ApplyNewOptionsForButtonsTextWrapper(
+		day_button_former.ChangeUnselectableDays(map[time.Time]struct{}{time.Date(2023, 6, 12, 0, 0, 0, 0, time.UTC): {}}),
+	))
+	previous := m.keyboardGenerator()
+
+	m.ApplyNewOptions(
+		generator.ChangeHomeButtonForBeauty("🏠"),
+		generator.ApplyNewOptionsForButtonsTextWrapper(day_button_former.ChangeUnselectableDays(nil)),
+	)
+
+	previousConfig := previous.GetCurrentConfig()
+	if previousConfig.HomeButtonForBeauty == "🏠" || len(previousConfig.UnselectableDays) != 1 {
+		t.Errorf("previous generator is changed: %+v", previousConfig)
+	}
+	if config := m.GetCurrentConfig(); config.HomeButtonForBeauty != "🏠" || len(config.UnselectableDays) != 0 {
+		t.Errorf("options are not applied: %+v", config)
+	}
+}
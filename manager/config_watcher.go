@@ -0,0 +1,201 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/thevan4/telegram-calendar/day_button_former"
+	"github.com/thevan4/telegram-calendar/generator"
+)
+
+// ErrNotRebuildableGenerator the config can not be applied to the manager own generator, see ConfigWatcher.
+var ErrNotRebuildableGenerator = errors.New("generator can not be rebuilt from the config")
+
+// The interval of Run for the not positive ones.
+const defaultConfigWatchInterval = time.Second
+
+// ReloadResult the result of the config file reload, Err is nil when the new settings are applied.
+// On errors the manager keeps the previous settings.
+type ReloadResult struct {
+	Path   string
+	Time   time.Time
+	Config FileConfig
+	Err    error
+}
+
+// ConfigWatcher polls the config file and swaps the manager generator when the file changes.
+// The new generator is built from scratch: the code settings of the current generator the file has no place for
+// (event source, load provider, day decorators and extra rows, set by ApplyNewOptions for example),
+// the config settings, then the watcher options, so the settings removed from the file return to the defaults.
+// The decorators of the manager generator
+// (generator.KeyboardGeneratorWrapper) are kept around the new one, own generator.ConfigurableKeyboardGenerator
+// implementations get the new settings with WithConfig. Other own generators are not rebuilt,
+// the reload fails with ErrNotRebuildableGenerator.
+type ConfigWatcher struct {
+	manager       *Manager
+	path          string
+	yamlUnmarshal Unmarshaler
+	onReload      func(ReloadResult)
+	options       []func(generator.KeyboardGenerator) generator.KeyboardGenerator
+
+	mu           sync.Mutex
+	lastState    fileState
+	pendingState fileState
+}
+
+// Modification time and size of the file, the zero value is "not checked yet".
+type fileState struct {
+	modTime time.Time
+	size    int64
+	missing bool
+}
+
+// NewConfigWatcher creates the watcher of the config file (see LoadFileConfig) for the manager.
+// onReload may be nil. The options are code settings the file has no place for (event source, for example),
+// they are applied after the config on every reload.
+func NewConfigWatcher(
+	m *Manager,
+	path string,
+	yamlUnmarshal Unmarshaler,
+	onReload func(ReloadResult),
+	options ...func(generator.KeyboardGenerator) generator.KeyboardGenerator,
+) *ConfigWatcher {
+	return &ConfigWatcher{
+		manager:       m,
+		path:          path,
+		yamlUnmarshal: yamlUnmarshal,
+		onReload:      onReload,
+		options:       options,
+	}
+}
+
+// Reload loads the file and swaps the generator now, whether the file is changed or not.
+func (w *ConfigWatcher) Reload() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.lastState = w.stat()
+	w.pendingState = w.lastState
+	return w.reload()
+}
+
+// Run reloads the config at once and then every time the file changes, the file is checked every interval.
+// The changed file is loaded when it is the same at two checks in a row, so a file being written is not read;
+// still, replacing the file by rename is safer than writing it in place.
+// A missing file is reported once, the manager keeps the previous settings until it is back. Blocks until ctx is done.
+// Not positive interval is one second.
+func (w *ConfigWatcher) Run(ctx context.Context, interval time.Duration) {
+	_ = w.Reload() // reported by the callback.
+
+	if interval <= 0 {
+		interval = defaultConfigWatchInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.reloadIfChanged()
+		}
+	}
+}
+
+func (w *ConfigWatcher) reloadIfChanged() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	state := w.stat()
+	if state == w.lastState {
+		w.pendingState = state
+		return
+	}
+	if state != w.pendingState {
+		w.pendingState = state
+		return
+	}
+	w.lastState = state
+
+	_ = w.reload() // reported by the callback.
+}
+
+func (w *ConfigWatcher) reload() error {
+	result := ReloadResult{Path: w.path, Time: time.Now()}
+	result.Config, result.Err = LoadFileConfig(w.path, w.yamlUnmarshal)
+	if result.Err == nil {
+		var options []func(generator.KeyboardGenerator) generator.KeyboardGenerator
+		options, result.Err = result.Config.Options()
+		if result.Err == nil {
+			result.Err = w.manager.rebuildKeyboardGenerator(append(options, w.options...)...)
+		}
+	}
+
+	if w.onReload != nil {
+		w.onReload(result)
+	}
+	return result.Err
+}
+
+// Publishes the generator rebuilt with the options, if its settings are valid.
+func (m *Manager) rebuildKeyboardGenerator(options ...func(generator.KeyboardGenerator) generator.KeyboardGenerator) error {
+	m.writeMu.Lock()
+	defer m.writeMu.Unlock()
+	options = append(codeSettingsOptions(m.keyboardGenerator().GetCurrentConfig()), options...)
+	keyboardFormer, err := rebuildGenerator(m.keyboardGenerator(), options...)
+	if err != nil {
+		return err
+	}
+	if err := keyboardFormer.GetCurrentConfig().Validate(); err != nil {
+		return err
+	}
+	m.publish(keyboardFormer)
+	return nil
+}
+
+// The options of the settings that are code and are not in the config file (see FileConfig).
+func codeSettingsOptions(config generator.FlatConfig) []func(generator.KeyboardGenerator) generator.KeyboardGenerator {
+	return []func(generator.KeyboardGenerator) generator.KeyboardGenerator{
+		generator.ChangeExtraRowsAbove(config.ExtraRowsAbove),
+		generator.ChangeExtraRowsBelow(config.ExtraRowsBelow),
+		generator.ChangeEventSource(config.EventSource),
+		generator.ApplyNewOptionsForButtonsTextWrapper(
+			day_button_former.ChangeDayDecorators(config.DayDecorators...),
+			day_button_former.ChangeLoadProvider(config.LoadProvider),
+		),
+	}
+}
+
+// The generator of the same kind as kg (the same decorators around it) with the default settings and the options.
+func rebuildGenerator(
+	kg generator.KeyboardGenerator,
+	options ...func(generator.KeyboardGenerator) generator.KeyboardGenerator,
+) (generator.KeyboardGenerator, error) {
+	switch k := kg.(type) {
+	case *generator.KeyboardFormer:
+		return generator.NewKeyboardFormer(options...), nil
+	case generator.KeyboardGeneratorWrapper:
+		wrapped, err := rebuildGenerator(k.Unwrap(), options...)
+		if err != nil {
+			return nil, err
+		}
+		return k.WithWrapped(wrapped), nil
+	case generator.ConfigurableKeyboardGenerator:
+		return k.WithConfig(generator.NewKeyboardFormer().GetCurrentConfig()).ApplyNewOptions(options...), nil
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrNotRebuildableGenerator, kg)
+	}
+}
+
+func (w *ConfigWatcher) stat() fileState {
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return fileState{missing: true}
+	}
+	return fileState{modTime: info.ModTime(), size: info.Size()}
+}
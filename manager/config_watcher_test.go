@@ -0,0 +1,241 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/thevan4/telegram-calendar/day_button_former"
+	"github.com/thevan4/telegram-calendar/generator"
+	"github.com/thevan4/telegram-calendar/models"
+)
+
+func TestConfigWatcherRun(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "calendar.json")
+	modTime := time.Now()
+	// Replaced by rename, so the watcher never sees a half written file.
+	writeConfig := func(content string) {
+		modTime = modTime.Add(time.Second)
+		tmpPath := path + ".tmp"
+		if err := os.WriteFile(tmpPath, []byte(content), 0o600); err != nil {
+			t.Fatalf("at os.WriteFile error: %v", err)
+		}
+		if err := os.Chtimes(tmpPath, modTime, modTime); err != nil {
+			t.Fatalf("at os.Chtimes error: %v", err)
+		}
+		if err := os.Rename(tmpPath, path); err != nil {
+			t.Fatalf("at os.Rename error: %v", err)
+		}
+	}
+	writeConfig(`{"years_back_for_choose": 1, "unselectable_days": {"dates": ["2023-12-25"]}}`)
+
+	results := make(chan ReloadResult, 10)
+	m := NewManager(generator.ChangeYearsBackForChoose(5))
+	watcher := NewConfigWatcher(m, path, nil, func(result ReloadResult) { results <- result },
+		generator.ChangeHomeButtonForBeauty("🏠"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		watcher.Run(ctx, time.Millisecond)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	waitResult := func() ReloadResult {
+		select {
+		case result := <-results:
+			return result
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no reload result")
+			return ReloadResult{}
+		}
+	}
+
+	// The first check loads the file.
+	if result := waitResult(); result.Err != nil || result.Path != path {
+		t.Errorf("unexpected first reload: %+v", result)
+	}
+	config := m.GetCurrentConfig()
+	if config.YearsBackForChoose != 1 || len(config.UnselectableDays) != 1 || config.HomeButtonForBeauty != "🏠" {
+		t.Errorf("unexpected config after the first reload: %+v", config)
+	}
+
+	// Wrong config is reported, the previous settings are kept.
+	writeConfig(`{"years_back_for_choose": -1}`)
+	result := waitResult()
+	var fieldErr *FieldError
+	if !errors.As(result.Err, &fieldErr) || fieldErr.Field != "years_back_for_choose" {
+		t.Errorf("unexpected wrong config reload error: %v", result.Err)
+	}
+	if config := m.GetCurrentConfig(); config.YearsBackForChoose != 1 || len(config.UnselectableDays) != 1 {
+		t.Errorf("previous config is not kept: %+v", config)
+	}
+
+	// Removed fields return to the defaults, the watcher options stay.
+	writeConfig(`{"years_forward_for_choose": 2}`)
+	if result := waitResult(); result.Err != nil || result.Config.YearsForwardForChoose != 2 {
+		t.Errorf("unexpected reload: %+v", result)
+	}
+	config = m.GetCurrentConfig()
+	if config.YearsBackForChoose != 0 || config.YearsForwardForChoose != 2 || len(config.UnselectableDays) != 0 ||
+		config.HomeButtonForBeauty != "🏠" {
+		t.Errorf("unexpected config after the reload: %+v", config)
+	}
+
+	// Missing file is reported once.
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("at os.Remove error: %v", err)
+	}
+	if result := waitResult(); !errors.Is(result.Err, os.ErrNotExist) {
+		t.Errorf("unexpected missing file error: %v", result.Err)
+	}
+	select {
+	case result := <-results:
+		t.Errorf("unexpected reload of the missing file: %+v", result)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if config := m.GetCurrentConfig(); config.YearsForwardForChoose != 2 {
+		t.Errorf("previous config is not kept: %+v", config)
+	}
+}
+
+func TestConfigWatcherRunWithNotPositiveInterval(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "calendar.json")
+	if err := os.WriteFile(path, []byte(`{"years_back_for_choose": 1}`), 0o600); err != nil {
+		t.Errorf("at os.WriteFile error: %v", err)
+		return
+	}
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		m := NewManager()
+		reloads := 0
+		watcher := NewConfigWatcher(m, path, nil, func(ReloadResult) { reloads++ })
+
+		// Done at once, so Run returns after the first reload.
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		watcher.Run(ctx, interval)
+
+		if reloads != 1 || m.GetCurrentConfig().YearsBackForChoose != 1 {
+			t.Errorf("%v: unexpected reloads %v, config %+v", interval, reloads, m.GetCurrentConfig())
+		}
+	}
+}
+
+func TestConfigWatcherReload(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "calendar.yaml")
+	if err := os.WriteFile(path, []byte(`{}`), 0o600); err != nil {
+		t.Errorf("at os.WriteFile error: %v", err)
+		return
+	}
+
+	m := NewManager(generator.ChangeYearsBackForChoose(5))
+	var reported error
+	watcher := NewConfigWatcher(m, path, nil, func(result ReloadResult) { reported = result.Err })
+
+	if err := watcher.Reload(); !errors.Is(err, ErrNoYAMLUnmarshaler) || !errors.Is(reported, ErrNoYAMLUnmarshaler) {
+		t.Errorf("unexpected reload errors: returned %v, reported %v", err, reported)
+	}
+	if config := m.GetCurrentConfig(); config.YearsBackForChoose != 5 {
+		t.Errorf("previous config is not kept: %+v", config)
+	}
+}
+
+func TestConfigWatcherReloadKeepsCodeSettings(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "calendar.json")
+	if err := os.WriteFile(path, []byte(`{"years_back_for_choose": 1}`), 0o600); err != nil {
+		t.Errorf("at os.WriteFile error: %v", err)
+		return
+	}
+
+	m := NewManager()
+	m.ApplyNewOptions(
+		generator.ChangeExtraRowsAbove([][]models.InlineKeyboardButton{{models.NewInlineKeyboardButton("No date", "my/no_date")}}),
+		generator.ChangeEventSource(generator.EventSourceContextFunc(
+			func(_ context.Context, _ int, _ time.Month, _ *time.Location) ([]models.Event, error) {
+				return nil, nil
+			})),
+		generator.ApplyNewOptionsForButtonsTextWrapper(
+			day_button_former.ChangeLoadProvider(day_button_former.LoadProviderFunc(func(time.Time) (float64, bool) {
+				return 0, false
+			})),
+		),
+	)
+
+	if err := NewConfigWatcher(m, path, nil, nil).Reload(); err != nil {
+		t.Errorf("unexpected reload error: %v", err)
+		return
+	}
+	config := m.GetCurrentConfig()
+	if config.YearsBackForChoose != 1 {
+		t.Errorf("config is not applied: %+v", config)
+	}
+	if len(config.ExtraRowsAbove) != 1 || config.EventSource == nil || config.LoadProvider == nil {
+		t.Errorf("code settings are dropped by the reload: %+v", config)
+	}
+}
+
+// Decorator of the manager generator.
+type wrapperAtManager struct {
+	generator.KeyboardGenerator
+}
+
+func (w wrapperAtManager) ApplyNewOptions(
+	options ...func(generator.KeyboardGenerator) generator.KeyboardGenerator,
+) generator.KeyboardGenerator {
+	return generator.ApplyOptions(w, options...)
+}
+
+func (w wrapperAtManager) Unwrap() generator.KeyboardGenerator {
+	return w.KeyboardGenerator
+}
+
+func (w wrapperAtManager) WithWrapped(wrapped generator.KeyboardGenerator) generator.KeyboardGenerator {
+	w.KeyboardGenerator = wrapped
+	return w
+}
+
+func TestConfigWatcherReloadOwnGenerators(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "calendar.json")
+	if err := os.WriteFile(path, []byte(`{"years_back_for_choose": 1}`), 0o600); err != nil {
+		t.Errorf("at os.WriteFile error: %v", err)
+		return
+	}
+
+	decorated := NewManagerWithGenerator(wrapperAtManager{KeyboardGenerator: generator.NewKeyboardFormer()},
+		generator.ChangeYearsBackForChoose(5))
+	if err := NewConfigWatcher(decorated, path, nil, nil).Reload(); err != nil {
+		t.Errorf("unexpected decorated generator reload error: %v", err)
+	}
+	if _, ok := decorated.keyboardGenerator().(wrapperAtManager); !ok {
+		t.Errorf("decorator is dropped by the reload: %T", decorated.keyboardGenerator())
+	}
+	if config := decorated.GetCurrentConfig(); config.YearsBackForChoose != 1 {
+		t.Errorf("config is not applied to the decorated generator: %+v", config)
+	}
+
+	opaque := NewManagerWithGenerator(opaqueGeneratorAtManager{KeyboardGenerator: generator.NewKeyboardFormer()})
+	if err := NewConfigWatcher(opaque, path, nil, nil).Reload(); !errors.Is(err, ErrNotRebuildableGenerator) {
+		t.Errorf("unexpected opaque generator reload error: %v", err)
+	}
+	if _, ok := opaque.keyboardGenerator().(opaqueGeneratorAtManager); !ok {
+		t.Errorf("opaque generator is replaced: %T", opaque.keyboardGenerator())
+	}
+}
@@ -0,0 +1,435 @@
+package manager
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thevan4/telegram-calendar/day_button_former"
+	"github.com/thevan4/telegram-calendar/generator"
+	"github.com/thevan4/telegram-calendar/payload_former"
+)
+
+// FileConfig is the serializable part of the manager settings, for JSON and YAML config files.
+// Start from DefaultFileConfig (the loaders do it), so the missing fields keep the defaults.
+// Event sources, load providers, day decorators and extra rows are code, they are set by options only.
+type FileConfig struct {
+	YearsBackForChoose    int                  `json:"years_back_for_choose" yaml:"years_back_for_choose"`
+	YearsForwardForChoose int                  `json:"years_forward_for_choose" yaml:"years_forward_for_choose"`
+	DaysNames             []string             `json:"days_names" yaml:"days_names"`
+	MonthNames            []string             `json:"month_names" yaml:"month_names"`
+	HomeButtonForBeauty   string               `json:"home_button_for_beauty" yaml:"home_button_for_beauty"`
+	HideHomeButton        bool                 `json:"hide_home_button" yaml:"hide_home_button"`
+	HeaderLayout          string               `json:"header_layout" yaml:"header_layout"`
+	PrevMonthName         string               `json:"prev_month_name" yaml:"prev_month_name"`
+	NextMonthName         string               `json:"next_month_name" yaml:"next_month_name"`
+	PrevYearName          string               `json:"prev_year_name" yaml:"prev_year_name"`
+	NextYearName          string               `json:"next_year_name" yaml:"next_year_name"`
+	RightToLeft           bool                 `json:"right_to_left" yaml:"right_to_left"`
+	NumeralSystem         string               `json:"numeral_system" yaml:"numeral_system"`
+	AdjacentDaysMode      string               `json:"adjacent_days_mode" yaml:"adjacent_days_mode"`
+	FixedWeeksRows        bool                 `json:"fixed_weeks_rows" yaml:"fixed_weeks_rows"`
+	HideDaysNames         bool                 `json:"hide_days_names" yaml:"hide_days_names"`
+	FooterButtons         []FileFooterButton   `json:"footer_buttons" yaml:"footer_buttons"`
+	EventMarker           string               `json:"event_marker" yaml:"event_marker"`
+	ShowEventsCount       bool                 `json:"show_events_count" yaml:"show_events_count"`
+	PayloadEncoderDecoder string               `json:"payload_encoder_decoder" yaml:"payload_encoder_decoder"`
+	DayButtons            FileDayButtonsConfig `json:"day_buttons" yaml:"day_buttons"`
+	UnselectableDays      FileUnselectableDays `json:"unselectable_days" yaml:"unselectable_days"`
+	Timezone              string               `json:"timezone" yaml:"timezone"`
+	LoadLevels            []FileLoadLevel      `json:"load_levels" yaml:"load_levels"`
+	FullLoadThreshold     float64              `json:"full_load_threshold" yaml:"full_load_threshold"`
+}
+
+// FileFooterButton footer button at the config file.
+type FileFooterButton struct {
+	// Action is one of "today", "clear", "cancel", "confirm".
+	Action string `json:"action" yaml:"action"`
+	Text   string `json:"text,omitempty" yaml:"text,omitempty"`
+}
+
+// FileDayButtonsConfig prefixes and postfixes of the days buttons.
+type FileDayButtonsConfig struct {
+	PrefixForCurrentDay      string `json:"prefix_for_current_day" yaml:"prefix_for_current_day"`
+	PostfixForCurrentDay     string `json:"postfix_for_current_day" yaml:"postfix_for_current_day"`
+	PrefixForNonSelectedDay  string `json:"prefix_for_non_selected_day" yaml:"prefix_for_non_selected_day"`
+	PostfixForNonSelectedDay string `json:"postfix_for_non_selected_day" yaml:"postfix_for_non_selected_day"`
+	PrefixForPickDay         string `json:"prefix_for_pick_day" yaml:"prefix_for_pick_day"`
+	PostfixForPickDay        string `json:"postfix_for_pick_day" yaml:"postfix_for_pick_day"`
+	PrefixForAdjacentDay     string `json:"prefix_for_adjacent_day" yaml:"prefix_for_adjacent_day"`
+	PostfixForAdjacentDay    string `json:"postfix_for_adjacent_day" yaml:"postfix_for_adjacent_day"`
+	PrefixForPreselectedDay  string `json:"prefix_for_preselected_day" yaml:"prefix_for_preselected_day"`
+	PostfixForPreselectedDay string `json:"postfix_for_preselected_day" yaml:"postfix_for_preselected_day"`
+}
+
+// FileUnselectableDays unavailable days rules. Times are RFC 3339 ("2023-01-01T00:00:00Z"),
+// dates are "2006-01-02" at the config timezone.
+type FileUnselectableDays struct {
+	Before string   `json:"before" yaml:"before"`
+	After  string   `json:"after" yaml:"after"`
+	Dates  []string `json:"dates" yaml:"dates"`
+	// MinimumLeadTime is a Go duration ("24h"), empty disables the rule.
+	MinimumLeadTime string `json:"minimum_lead_time" yaml:"minimum_lead_time"`
+}
+
+// FileLoadLevel load level marker at the config file.
+type FileLoadLevel struct {
+	From   float64 `json:"from" yaml:"from"`
+	Marker string  `json:"marker" yaml:"marker"`
+}
+
+// FieldError points at the config field with the wrong value, like "footer_buttons[1].action".
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return "config field " + e.Field + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Config validation errors of the file fields, wrapped by FieldError.
+// The values checks are wrapped the same way and have the generator errors (generator.ErrUnknownMode, ...).
+var (
+	ErrWrongNamesCount = errors.New("wrong number of names")
+	ErrWrongTime       = errors.New("must be RFC 3339 time or 2006-01-02 date")
+	ErrWrongDuration   = errors.New("must be duration like 24h")
+	ErrUnknownField    = errors.New("unknown field")
+)
+
+const dateLayout = "2006-01-02"
+
+//nolint:gochecknoglobals
+var (
+	numeralSystemsByName = map[string]day_button_former.NumeralSystem{
+		"latin":        day_button_former.NumeralsLatin,
+		"arabic-indic": day_button_former.NumeralsArabicIndic,
+		"persian":      day_button_former.NumeralsPersian,
+		"devanagari":   day_button_former.NumeralsDevanagari,
+		"bengali":      day_button_former.NumeralsBengali,
+		"thai":         day_button_former.NumeralsThai,
+	}
+	headerLayoutsByName = map[string]generator.HeaderLayout{
+		"full":     generator.HeaderLayoutFull,
+		"two-rows": generator.HeaderLayoutTwoRows,
+		"compact":  generator.HeaderLayoutCompact,
+	}
+	adjacentDaysModesByName = map[string]generator.AdjacentDaysMode{
+		"hidden":   generator.AdjacentDaysHidden,
+		"select":   generator.AdjacentDaysSelect,
+		"navigate": generator.AdjacentDaysNavigate,
+	}
+	footerActionsByName = map[string]generator.FooterAction{
+		"today":   generator.FooterActionToday,
+		"clear":   generator.FooterActionClear,
+		"cancel":  generator.FooterActionCancel,
+		"confirm": generator.FooterActionConfirm,
+	}
+)
+
+// DefaultFileConfig the settings of NewManager without options.
+func DefaultFileConfig() FileConfig {
+	fc := fileConfigValues(newDefaultManager().GetCurrentConfig())
+	// The names of the defaults, not looked up: the registry entries may be replaced by the callers.
+	fc.HeaderLayout = "full"
+	fc.NumeralSystem = "latin"
+	fc.AdjacentDaysMode = "hidden"
+	fc.PayloadEncoderDecoder = payload_former.EncoderDecoderNameDefault
+	return fc
+}
+
+// NewManagerFromConfig creates the manager with the config settings, the options are applied after them.
+// The result is validated like NewManagerE.
+func NewManagerFromConfig(
+	config FileConfig,
+	options ...func(generator.KeyboardGenerator) generator.KeyboardGenerator,
+) (*Manager, error) {
+	configOptions, err := config.Options()
+	if err != nil {
+		return nil, err
+	}
+	return NewManagerE(append(configOptions, options...)...)
+}
+
+// Options validates the config and converts it to the generator options.
+// The error is *FieldError for the first wrong field.
+func (fc FileConfig) Options() ([]func(generator.KeyboardGenerator) generator.KeyboardGenerator, error) {
+	generatorOptions, err := fc.generatorOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	dayButtonsOptions, err := fc.dayButtonsOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(generatorOptions, generator.ApplyNewOptionsForButtonsTextWrapper(dayButtonsOptions...)), nil
+}
+
+//nolint:funlen,cyclop // flat list of fields.
+func (fc FileConfig) generatorOptions() ([]func(generator.KeyboardGenerator) generator.KeyboardGenerator, error) {
+	if fc.YearsBackForChoose < 0 {
+		return nil, &FieldError{Field: "years_back_for_choose", Err: generator.ErrNegativeValue}
+	}
+	if fc.YearsForwardForChoose < 0 {
+		return nil, &FieldError{Field: "years_forward_for_choose", Err: generator.ErrNegativeValue}
+	}
+
+	var daysNames [7]string
+	if len(fc.DaysNames) != len(daysNames) {
+		return nil, &FieldError{Field: "days_names", Err: countError(len(daysNames), len(fc.DaysNames))}
+	}
+	copy(daysNames[:], fc.DaysNames)
+
+	var monthNames [12]string
+	if len(fc.MonthNames) != len(monthNames) {
+		return nil, &FieldError{Field: "month_names", Err: countError(len(monthNames), len(fc.MonthNames))}
+	}
+	copy(monthNames[:], fc.MonthNames)
+
+	headerLayout, ok := headerLayoutsByName[fc.HeaderLayout]
+	if !ok {
+		return nil, &FieldError{Field: "header_layout", Err: valueError(fc.HeaderLayout)}
+	}
+	numeralSystem, ok := numeralSystemsByName[fc.NumeralSystem]
+	if !ok {
+		return nil, &FieldError{Field: "numeral_system", Err: valueError(fc.NumeralSystem)}
+	}
+	adjacentDaysMode, ok := adjacentDaysModesByName[fc.AdjacentDaysMode]
+	if !ok {
+		return nil, &FieldError{Field: "adjacent_days_mode", Err: valueError(fc.AdjacentDaysMode)}
+	}
+	payloadEncoderDecoder, ok := payload_former.LookupEncoderDecoder(fc.PayloadEncoderDecoder)
+	if !ok {
+		return nil, &FieldError{Field: "payload_encoder_decoder", Err: valueError(fc.PayloadEncoderDecoder)}
+	}
+
+	footerButtons := make([]generator.FooterButton, 0, len(fc.FooterButtons))
+	for i, button := range fc.FooterButtons {
+		action, ok := footerActionsByName[button.Action]
+		if !ok {
+			return nil, &FieldError{Field: "footer_buttons[" + strconv.Itoa(i) + "].action", Err: valueError(button.Action)}
+		}
+		footerButtons = append(footerButtons, generator.FooterButton{Action: action, Text: button.Text})
+	}
+
+	return []func(generator.KeyboardGenerator) generator.KeyboardGenerator{
+		generator.ChangeYearsBackForChoose(fc.YearsBackForChoose),
+		generator.ChangeYearsForwardForChoose(fc.YearsForwardForChoose),
+		generator.ChangeDaysNames(daysNames),
+		generator.ChangeMonthNames(monthNames),
+		generator.ChangeHomeButtonForBeauty(fc.HomeButtonForBeauty),
+		generator.ChangeHideHomeButton(fc.HideHomeButton),
+		generator.ChangeHeaderLayout(headerLayout),
+		generator.ChangePrevMonthName(fc.PrevMonthName),
+		generator.ChangeNextMonthName(fc.NextMonthName),
+		generator.ChangePrevYearName(fc.PrevYearName),
+		generator.ChangeNextYearName(fc.NextYearName),
+		generator.ChangeRightToLeft(fc.RightToLeft),
+		generator.ChangeNumeralSystem(numeralSystem),
+		generator.ChangeAdjacentDaysMode(adjacentDaysMode),
+		generator.ChangeFixedWeeksRows(fc.FixedWeeksRows),
+		generator.ChangeHideDaysNames(fc.HideDaysNames),
+		generator.ChangeFooterButtons(footerButtons...),
+		generator.ChangeEventMarker(fc.EventMarker),
+		generator.ChangeShowEventsCount(fc.ShowEventsCount),
+		generator.ChangePayloadEncoderDecoder(payloadEncoderDecoder),
+	}, nil
+}
+
+//nolint:funlen,cyclop // flat list of fields.
+func (fc FileConfig) dayButtonsOptions() ([]func(day_button_former.DaysButtonsText) day_button_former.DaysButtonsText, error) {
+	timezone, err := loadConfigLocation(fc.Timezone)
+	if err != nil {
+		return nil, &FieldError{Field: "timezone", Err: valueError(fc.Timezone)}
+	}
+
+	before, err := parseConfigTime(fc.UnselectableDays.Before, timezone)
+	if err != nil {
+		return nil, &FieldError{Field: "unselectable_days.before", Err: err}
+	}
+	after, err := parseConfigTime(fc.UnselectableDays.After, timezone)
+	if err != nil {
+		return nil, &FieldError{Field: "unselectable_days.after", Err: err}
+	}
+
+	unselectableDays := make(map[time.Time]struct{}, len(fc.UnselectableDays.Dates))
+	for i, date := range fc.UnselectableDays.Dates {
+		day, err := parseConfigDate(date, timezone)
+		if err != nil {
+			return nil, &FieldError{Field: "unselectable_days.dates[" + strconv.Itoa(i) + "]", Err: ErrWrongTime}
+		}
+		unselectableDays[day] = struct{}{}
+	}
+
+	var minimumLeadTime time.Duration
+	if fc.UnselectableDays.MinimumLeadTime != "" {
+		minimumLeadTime, err = time.ParseDuration(fc.UnselectableDays.MinimumLeadTime)
+		if err != nil {
+			return nil, &FieldError{Field: "unselectable_days.minimum_lead_time", Err: ErrWrongDuration}
+		}
+	}
+
+	loadLevels := make([]day_button_former.LoadLevel, 0, len(fc.LoadLevels))
+	for i, level := range fc.LoadLevels {
+		if level.From < 0 || level.From > 1 || math.IsNaN(level.From) {
+			return nil, &FieldError{Field: "load_levels[" + strconv.Itoa(i) + "].from", Err: generator.ErrLoadOutOfRange}
+		}
+		loadLevels = append(loadLevels, day_button_former.LoadLevel{From: level.From, Marker: level.Marker})
+	}
+	if fc.FullLoadThreshold < 0 || math.IsNaN(fc.FullLoadThreshold) {
+		return nil, &FieldError{Field: "full_load_threshold", Err: generator.ErrNegativeValue}
+	}
+
+	db := fc.DayButtons
+	return []func(day_button_former.DaysButtonsText) day_button_former.DaysButtonsText{
+		// The timezone goes first, the dates are at it.
+		day_button_former.ChangeTimezone(timezone),
+		day_button_former.ChangeUnselectableDaysBeforeDate(before),
+		day_button_former.ChangeUnselectableDaysAfterDate(after),
+		day_button_former.ChangeUnselectableDays(unselectableDays),
+		day_button_former.ChangeMinimumLeadTime(minimumLeadTime),
+		day_button_former.ChangePrefixForCurrentDay(db.PrefixForCurrentDay),
+		day_button_former.ChangePostfixForCurrentDay(db.PostfixForCurrentDay),
+		day_button_former.ChangePrefixForNonSelectedDay(db.PrefixForNonSelectedDay),
+		day_button_former.ChangePostfixForNonSelectedDay(db.PostfixForNonSelectedDay),
+		day_button_former.ChangePrefixForPickDay(db.PrefixForPickDay),
+		day_button_former.ChangePostfixForPickDay(db.PostfixForPickDay),
+		day_button_former.ChangePrefixForAdjacentDay(db.PrefixForAdjacentDay),
+		day_button_former.ChangePostfixForAdjacentDay(db.PostfixForAdjacentDay),
+		day_button_former.ChangePrefixForPreselectedDay(db.PrefixForPreselectedDay),
+		day_button_former.ChangePostfixForPreselectedDay(db.PostfixForPreselectedDay),
+		day_button_former.ChangeLoadLevels(loadLevels...),
+		day_button_former.ChangeFullLoadThreshold(fc.FullLoadThreshold),
+	}, nil
+}
+
+// IANA name or the fixed UTC offset like "UTC+03:00", the name of the timezone picker offset locations.
+func loadConfigLocation(name string) (*time.Location, error) {
+	if offset, ok := parseUTCOffset(name); ok {
+		return time.FixedZone(name, offset), nil
+	}
+	if name == "" {
+		return nil, generator.ErrUnknownMode
+	}
+	return time.LoadLocation(name)
+}
+
+// "UTC+03:00" -> 10800 seconds.
+func parseUTCOffset(name string) (int, bool) {
+	offset, ok := strings.CutPrefix(name, "UTC")
+	if !ok || len(offset) != len("+00:00") {
+		return 0, false
+	}
+
+	sign := 1
+	switch offset[0] {
+	case '+':
+	case '-':
+		sign = -1
+	default:
+		return 0, false
+	}
+
+	t, err := time.Parse("15:04", offset[1:])
+	if err != nil {
+		return 0, false
+	}
+	return sign * (t.Hour()*60*60 + t.Minute()*60), true
+}
+
+// RFC 3339 time or the date midnight at the location.
+func parseConfigTime(value string, location *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation(dateLayout, value, location); err == nil {
+		return t, nil
+	}
+	return time.Time{}, ErrWrongTime
+}
+
+// The date midnight at the location, RFC 3339 time gives the date it is written with
+// (YAML libraries decode the unquoted dates as times).
+func parseConfigDate(value string, location *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, location), nil
+	}
+	return time.ParseInLocation(dateLayout, value, location)
+}
+
+func countError(want, got int) error {
+	return fmt.Errorf("%w, want %d, got %d", ErrWrongNamesCount, want, got)
+}
+
+func valueError(value string) error {
+	return fmt.Errorf("%w %q", generator.ErrUnknownMode, value)
+}
+
+// The settings that are stored as they are, the named ones (header layout, numeral system,
+// adjacent days mode, payload encoder and footer actions) are set by the callers.
+func fileConfigValues(flat FlatConfig) FileConfig {
+	timezone := flat.Timezone
+	fc := FileConfig{
+		YearsBackForChoose:    flat.YearsBackForChoose,
+		YearsForwardForChoose: flat.YearsForwardForChoose,
+		DaysNames:             append([]string(nil), flat.DaysNames[:]...),
+		MonthNames:            append([]string(nil), flat.MonthNames[:]...),
+		HomeButtonForBeauty:   flat.HomeButtonForBeauty,
+		HideHomeButton:        flat.HideHomeButton,
+		PrevMonthName:         flat.PrevMonthName,
+		NextMonthName:         flat.NextMonthName,
+		PrevYearName:          flat.PrevYearName,
+		NextYearName:          flat.NextYearName,
+		RightToLeft:           flat.RightToLeft,
+		FixedWeeksRows:        flat.FixedWeeksRows,
+		HideDaysNames:         flat.HideDaysNames,
+		FooterButtons:         make([]FileFooterButton, 0, len(flat.FooterButtons)),
+		EventMarker:           flat.EventMarker,
+		ShowEventsCount:       flat.ShowEventsCount,
+		DayButtons: FileDayButtonsConfig{
+			PrefixForCurrentDay:      flat.PrefixForCurrentDay,
+			PostfixForCurrentDay:     flat.PostfixForCurrentDay,
+			PrefixForNonSelectedDay:  flat.PrefixForNonSelectedDay,
+			PostfixForNonSelectedDay: flat.PostfixForNonSelectedDay,
+			PrefixForPickDay:         flat.PrefixForPickDay,
+			PostfixForPickDay:        flat.PostfixForPickDay,
+			PrefixForAdjacentDay:     flat.PrefixForAdjacentDay,
+			PostfixForAdjacentDay:    flat.PostfixForAdjacentDay,
+			PrefixForPreselectedDay:  flat.PrefixForPreselectedDay,
+			PostfixForPreselectedDay: flat.PostfixForPreselectedDay,
+		},
+		UnselectableDays: FileUnselectableDays{
+			Before: flat.UnselectableDaysBeforeTime.Format(time.RFC3339Nano),
+			After:  flat.UnselectableDaysAfterTime.Format(time.RFC3339Nano),
+			Dates:  make([]string, 0, len(flat.UnselectableDays)),
+		},
+		Timezone:          timezone.String(),
+		LoadLevels:        make([]FileLoadLevel, 0, len(flat.LoadLevels)),
+		FullLoadThreshold: flat.FullLoadThreshold,
+	}
+
+	for day := range flat.UnselectableDays {
+		fc.UnselectableDays.Dates = append(fc.UnselectableDays.Dates, day.In(&timezone).Format(dateLayout))
+	}
+	sort.Strings(fc.UnselectableDays.Dates)
+
+	if flat.MinimumLeadTime > 0 {
+		fc.UnselectableDays.MinimumLeadTime = flat.MinimumLeadTime.String()
+	}
+
+	for _, level := range flat.LoadLevels {
+		fc.LoadLevels = append(fc.LoadLevels, FileLoadLevel{From: level.From, Marker: level.Marker})
+	}
+
+	return fc
+}
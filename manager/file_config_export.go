@@ -0,0 +1,102 @@
+package manager
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/thevan4/telegram-calendar/day_button_former"
+	"github.com/thevan4/telegram-calendar/generator"
+	"github.com/thevan4/telegram-calendar/payload_former"
+)
+
+// ExportFileConfig converts the settings to the config the loaders read back, the reverse of Options.
+// The timezone is stored by IANA name, times as RFC 3339, dates as 2006-01-02 and the payload encoder by registered name.
+// Fixed zones are stored as UTC offsets ("UTC+03:00"), so the timezone picker locations are exported too.
+// Values without a name (custom numeral system, not registered encoder) are *FieldError.
+// Event sources, load providers, day decorators and extra rows are code, they are not exported.
+func ExportFileConfig(flat FlatConfig) (FileConfig, error) {
+	fc := fileConfigValues(flat)
+
+	var ok bool
+	if fc.Timezone, ok = configLocationName(&flat.Timezone); !ok {
+		return FileConfig{}, &FieldError{Field: "timezone", Err: valueError(flat.Timezone.String())}
+	}
+	if fc.HeaderLayout, ok = nameOf(headerLayoutsByName, flat.HeaderLayout); !ok {
+		return FileConfig{}, &FieldError{Field: "header_layout", Err: generator.ErrUnknownMode}
+	}
+	numeralSystem := flat.NumeralSystem
+	if numeralSystem == (day_button_former.NumeralSystem{}) {
+		numeralSystem = day_button_former.NumeralsLatin // zero value works as Latin.
+	}
+	if fc.NumeralSystem, ok = nameOf(numeralSystemsByName, numeralSystem); !ok {
+		return FileConfig{}, &FieldError{Field: "numeral_system", Err: generator.ErrUnknownMode}
+	}
+	if fc.AdjacentDaysMode, ok = nameOf(adjacentDaysModesByName, flat.AdjacentDaysMode); !ok {
+		return FileConfig{}, &FieldError{Field: "adjacent_days_mode", Err: generator.ErrUnknownMode}
+	}
+	if fc.PayloadEncoderDecoder, ok = payload_former.LookupEncoderDecoderName(flat.PayloadEncoderDecoder); !ok {
+		return FileConfig{}, &FieldError{Field: "payload_encoder_decoder", Err: generator.ErrUnknownMode}
+	}
+
+	for i, button := range flat.FooterButtons {
+		action, ok := nameOf(footerActionsByName, button.Action)
+		if !ok {
+			return FileConfig{}, &FieldError{Field: "footer_buttons[" + strconv.Itoa(i) + "].action", Err: generator.ErrUnknownMode}
+		}
+		fc.FooterButtons = append(fc.FooterButtons, FileFooterButton{Action: action, Text: button.Text})
+	}
+
+	return fc, nil
+}
+
+// ExportConfig the current settings as the config file, see ExportFileConfig.
+func (m *Manager) ExportConfig() (FileConfig, error) {
+	return ExportFileConfig(m.GetCurrentConfig())
+}
+
+// ExportConfigJSON the current settings as the indented JSON config, LoadFileConfigJSON reads it back.
+// Fields are always in the same order and dates are sorted, so the documents can be diffed.
+func (m *Manager) ExportConfigJSON() ([]byte, error) {
+	fc, err := m.ExportConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(fc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// The name loadConfigLocation reads back: IANA name or "UTC+03:00" for the fixed zones.
+func configLocationName(location *time.Location) (string, bool) {
+	name := location.String()
+	if _, err := loadConfigLocation(name); err == nil {
+		return name, true
+	}
+
+	// The same offset in winter and in summer, so the zone is fixed.
+	_, winterOffset := time.Date(2000, time.January, 1, 0, 0, 0, 0, location).Zone()
+	_, summerOffset := time.Date(2000, time.July, 1, 0, 0, 0, 0, location).Zone()
+	if winterOffset != summerOffset {
+		return "", false
+	}
+
+	sign := "+"
+	if winterOffset < 0 {
+		sign, winterOffset = "-", -winterOffset
+	}
+	return fmt.Sprintf("UTC%s%02d:%02d", sign, winterOffset/(60*60), winterOffset%(60*60)/60), true
+}
+
+func nameOf[T comparable](names map[string]T, value T) (string, bool) {
+	for name, v := range names {
+		if v == value {
+			return name, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,177 @@
+package manager
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thevan4/telegram-calendar/day_button_former"
+	"github.com/thevan4/telegram-calendar/generator"
+	"github.com/thevan4/telegram-calendar/payload_former"
+)
+
+type notRegisteredEncoderDecoder struct {
+	payload_former.EncoderDecoder
+}
+
+func TestExportConfigJSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tzEuropeB, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Errorf("at time.LoadLocation for Europe/Berlin error: %v", err)
+		return
+	}
+
+	m := NewManager(
+		generator.ChangeYearsBackForChoose(2),
+		generator.ChangeHeaderLayout(generator.HeaderLayoutTwoRows),
+		generator.ChangeAdjacentDaysMode(generator.AdjacentDaysNavigate),
+		generator.ChangeFooterButtons(generator.FooterButton{Action: generator.FooterActionClear, Text: "✖"}),
+		generator.ChangeNumeralSystem(day_button_former.NumeralsThai),
+		generator.ApplyNewOptionsForButtonsTextWrapper(
+			day_button_former.ChangeTimezone(tzEuropeB),
+			day_button_former.ChangeUnselectableDaysBeforeDate(time.Date(2023, 1, 2, 3, 4, 5, 600, time.UTC)),
+			day_button_former.ChangeUnselectableDays(map[time.Time]struct{}{
+				time.Date(2023, 6, 13, 0, 0, 0, 0, tzEuropeB): {},
+				time.Date(2023, 6, 12, 0, 0, 0, 0, tzEuropeB): {},
+			}),
+			day_button_former.ChangeMinimumLeadTime(90*time.Minute),
+		),
+	)
+
+	exported, err := m.ExportConfigJSON()
+	if err != nil {
+		t.Errorf("at ExportConfigJSON error: %v", err)
+		return
+	}
+	for _, want := range []string{
+		`"timezone": "Europe/Berlin"`,
+		`"before": "2023-01-02T04:04:05.0000006+01:00"`,
+		`"dates": [
+      "2023-06-12",
+      "2023-06-13"
+    ]`,
+		`"minimum_lead_time": "1h30m0s"`,
+		`"payload_encoder_decoder": "default"`,
+		`"numeral_system": "thai"`,
+	} {
+		if !strings.Contains(string(exported), want) {
+			t.Errorf("exported config has no %s:\n%s", want, exported)
+		}
+	}
+
+	fc, err := LoadFileConfigJSON(bytes.NewReader(exported))
+	if err != nil {
+		t.Errorf("at LoadFileConfigJSON error: %v", err)
+		return
+	}
+	loaded, err := NewManagerFromConfig(fc)
+	if err != nil {
+		t.Errorf("at NewManagerFromConfig error: %v", err)
+		return
+	}
+	reexported, err := loaded.ExportConfigJSON()
+	if err != nil {
+		t.Errorf("at ExportConfigJSON of the loaded manager error: %v", err)
+		return
+	}
+	if !bytes.Equal(exported, reexported) {
+		t.Errorf("config changed after the round trip:\n%s\nreexported:\n%s", exported, reexported)
+	}
+}
+
+func TestExportFileConfigTimezones(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		timezone *time.Location
+		want     string
+	}{
+		{name: "utc", timezone: time.UTC, want: "UTC"},
+		{name: "timezone picker offset", timezone: time.FixedZone("UTC+05:30", 5*60*60+30*60), want: "UTC+05:30"},
+		{name: "fixed zone", timezone: time.FixedZone("UTC+3", 3*60*60), want: "UTC+03:00"},
+		{name: "negative fixed zone", timezone: time.FixedZone("", -(9*60*60 + 30*60)), want: "UTC-09:30"},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fc, err := NewManager(
+				generator.ApplyNewOptionsForButtonsTextWrapper(day_button_former.ChangeTimezone(tt.timezone)),
+			).ExportConfig()
+			if err != nil {
+				t.Errorf("at ExportConfig error: %v", err)
+				return
+			}
+			if fc.Timezone != tt.want {
+				t.Errorf("unexpected timezone: got: %v, want: %v", fc.Timezone, tt.want)
+			}
+
+			m, err := NewManagerFromConfig(fc)
+			if err != nil {
+				t.Errorf("at NewManagerFromConfig error: %v", err)
+				return
+			}
+			timezone := m.GetCurrentConfig().Timezone
+			_, gotOffset := time.Date(2023, 6, 1, 0, 0, 0, 0, &timezone).Zone()
+			_, wantOffset := time.Date(2023, 6, 1, 0, 0, 0, 0, tt.timezone).Zone()
+			if gotOffset != wantOffset {
+				t.Errorf("unexpected loaded offset: got: %v, want: %v", gotOffset, wantOffset)
+			}
+		},
+		)
+	}
+}
+
+func TestExportFileConfigZeroNumeralSystem(t *testing.T) {
+	t.Parallel()
+
+	fc, err := NewManager(generator.ChangeNumeralSystem(day_button_former.NumeralSystem{})).ExportConfig()
+	if err != nil || fc.NumeralSystem != "latin" {
+		t.Errorf("unexpected zero numeral system export: %v, %v", fc.NumeralSystem, err)
+	}
+}
+
+func TestExportFileConfigErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		option    func(generator.KeyboardGenerator) generator.KeyboardGenerator
+		wantField string
+	}{
+		{
+			name:      "custom numeral system",
+			option:    generator.ChangeNumeralSystem(day_button_former.NumeralSystem{'a', 'b', 'c', 'd', 'e', 'f', 'g', 'h', 'i', 'j'}),
+			wantField: "numeral_system",
+		},
+		{
+			name:      "not registered encoder",
+			option:    generator.ChangePayloadEncoderDecoder(notRegisteredEncoderDecoder{}),
+			wantField: "payload_encoder_decoder",
+		},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := NewManager(tt.option).ExportConfigJSON()
+
+			var fieldErr *FieldError
+			if !errors.As(err, &fieldErr) || !errors.Is(err, generator.ErrUnknownMode) {
+				t.Errorf("expected unknown value field error, got: %v", err)
+				return
+			}
+			if fieldErr.Field != tt.wantField {
+				t.Errorf("unexpected field: got: %v, want: %v", fieldErr.Field, tt.wantField)
+			}
+		},
+		)
+	}
+}
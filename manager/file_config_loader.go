@@ -0,0 +1,211 @@
+package manager
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/thevan4/telegram-calendar/generator"
+)
+
+// Unmarshaler decodes the config file data into v, yaml.Unmarshal of any YAML library fits.
+// The library is up to the caller, so the module has no YAML dependency.
+type Unmarshaler func(data []byte, v interface{}) error
+
+// Loader errors.
+var (
+	ErrNoYAMLUnmarshaler     = errors.New("yaml unmarshaler is not set")
+	ErrUnknownConfigFileType = errors.New("unknown config file type, want .json, .yaml or .yml")
+	ErrNotStringKey          = errors.New("config key is not a string")
+)
+
+// LoadFileConfigJSON reads the JSON config on top of DefaultFileConfig and validates it
+// like the generator config (see generator.FlatConfig.Validate), each error is *FieldError.
+// Unknown fields are errors, so typos do not silently keep the defaults.
+func LoadFileConfigJSON(r io.Reader) (FileConfig, error) {
+	fc := DefaultFileConfig()
+
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&fc); err != nil {
+		return FileConfig{}, jsonFieldError(err)
+	}
+
+	options, err := fc.Options()
+	if err != nil {
+		return FileConfig{}, err
+	}
+	if err := validateConfig(options); err != nil {
+		return FileConfig{}, err
+	}
+	return fc, nil
+}
+
+// All problems of the generator built with the options are joined, each *generator.ConfigError is wrapped
+// by *FieldError with the config field name.
+func validateConfig(options []func(generator.KeyboardGenerator) generator.KeyboardGenerator) error {
+	err := generator.NewKeyboardFormer(options...).GetCurrentConfig().Validate()
+	if err == nil {
+		return nil
+	}
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return err
+	}
+	errs := make([]error, 0, len(joined.Unwrap()))
+	for _, err := range joined.Unwrap() {
+		var configErr *generator.ConfigError
+		if errors.As(err, &configErr) {
+			err = &FieldError{Field: configFieldName(configErr.Field), Err: configErr.Err}
+		}
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// The config field of the generator.FlatConfig one: "FooterButtons[1].Action" is "footer_buttons[1].action".
+func configFieldName(flatField string) string {
+	switch flatField {
+	case "SumYearsForChoose":
+		return "years_forward_for_choose"
+	case "UnselectableDaysAfterTime":
+		return "unselectable_days.after"
+	}
+
+	var name strings.Builder
+	for i, r := range flatField {
+		if unicode.IsUpper(r) {
+			if i > 0 && flatField[i-1] != '.' {
+				name.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		name.WriteRune(r)
+	}
+	return name.String()
+}
+
+// LoadFileConfigYAML reads the YAML config on top of DefaultFileConfig with the caller's unmarshaler and validates it.
+// The document goes through LoadFileConfigJSON, so unknown fields and wrong types are *FieldError the same way.
+func LoadFileConfigYAML(r io.Reader, unmarshal Unmarshaler) (FileConfig, error) {
+	if unmarshal == nil {
+		return FileConfig{}, ErrNoYAMLUnmarshaler
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return FileConfig{}, fmt.Errorf("read yaml config: %w", err)
+	}
+
+	var document interface{}
+	if err := unmarshal(data, &document); err != nil {
+		return FileConfig{}, fmt.Errorf("decode yaml config: %w", err)
+	}
+	if document, err = jsonValue(document); err != nil {
+		return FileConfig{}, fmt.Errorf("decode yaml config: %w", err)
+	}
+	if data, err = json.Marshal(document); err != nil {
+		return FileConfig{}, fmt.Errorf("decode yaml config: %w", err)
+	}
+
+	return LoadFileConfigJSON(bytes.NewReader(data))
+}
+
+// LoadFileConfig reads the config file by its extension: .json, .yaml or .yml.
+// yamlUnmarshal may be nil for JSON files.
+func LoadFileConfig(path string, yamlUnmarshal Unmarshaler) (FileConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return FileConfig{}, fmt.Errorf("read config file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return LoadFileConfigJSON(bytes.NewReader(data))
+	case ".yaml", ".yml":
+		return LoadFileConfigYAML(bytes.NewReader(data), yamlUnmarshal)
+	default:
+		return FileConfig{}, ErrUnknownConfigFileType
+	}
+}
+
+// NewManagerFromConfigFile NewManagerFromConfig with LoadFileConfig.
+func NewManagerFromConfigFile(
+	path string,
+	yamlUnmarshal Unmarshaler,
+	options ...func(generator.KeyboardGenerator) generator.KeyboardGenerator,
+) (*Manager, error) {
+	fc, err := LoadFileConfig(path, yamlUnmarshal)
+	if err != nil {
+		return nil, err
+	}
+	return NewManagerFromConfig(fc, options...)
+}
+
+// Converts the YAML libraries values to the ones json.Marshal writes like the JSON config has them:
+// map[interface{}]interface{} mappings (gopkg.in/yaml.v2) to map[string]interface{}
+// and unquoted timestamps to RFC 3339 strings, the dates stay the date-only strings the library keeps.
+func jsonValue(value interface{}) (interface{}, error) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, v := range value {
+			converted, err := jsonValue(v)
+			if err != nil {
+				return nil, err
+			}
+			value[key] = converted
+		}
+		return value, nil
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(value))
+		for key, v := range value {
+			name, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: %v", ErrNotStringKey, key)
+			}
+			convertedValue, err := jsonValue(v)
+			if err != nil {
+				return nil, err
+			}
+			converted[name] = convertedValue
+		}
+		return converted, nil
+	case []interface{}:
+		for i, v := range value {
+			converted, err := jsonValue(v)
+			if err != nil {
+				return nil, err
+			}
+			value[i] = converted
+		}
+		return value, nil
+	case time.Time:
+		return value.Format(time.RFC3339Nano), nil
+	default:
+		return value, nil
+	}
+}
+
+// Wraps type and unknown field errors of encoding/json with FieldError.
+func jsonFieldError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return &FieldError{Field: typeErr.Field, Err: fmt.Errorf("must be %s, got %s", typeErr.Type, typeErr.Value)}
+	}
+
+	// There is no typed error for unknown fields: `json: unknown field "name"`.
+	const unknownFieldPrefix = "json: unknown field "
+	if message := err.Error(); strings.HasPrefix(message, unknownFieldPrefix) {
+		return &FieldError{Field: strings.Trim(strings.TrimPrefix(message, unknownFieldPrefix), `"`), Err: ErrUnknownField}
+	}
+
+	return fmt.Errorf("decode json config: %w", err)
+}
@@ -0,0 +1,362 @@
+package manager
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thevan4/telegram-calendar/day_button_former"
+	"github.com/thevan4/telegram-calendar/generator"
+)
+
+func TestNewManagerFromDefaultFileConfig(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewManagerFromConfig(DefaultFileConfig())
+	if err != nil {
+		t.Errorf("at NewManagerFromConfig error: %v", err)
+		return
+	}
+
+	gotConfig := m.GetCurrentConfig()
+	expectedConfig := newDefaultManager().GetCurrentConfig()
+	if !reflect.DeepEqual(gotConfig, expectedConfig) {
+		t.Errorf("default file config is not the default config: got %+v, expected %+v", gotConfig, expectedConfig)
+	}
+}
+
+func TestLoadFileConfigJSON(t *testing.T) {
+	t.Parallel()
+
+	tzEuropeB, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Errorf("at time.LoadLocation for Europe/Berlin error: %v", err)
+		return
+	}
+
+	input := `{
+		"years_back_for_choose": 1,
+		"days_names": ["Пн", "Вт", "Ср", "Чт", "Пт", "Сб", "Вс"],
+		"home_button_for_beauty": "🏠",
+		"header_layout": "compact",
+		"numeral_system": "persian",
+		"footer_buttons": [{"action": "today"}, {"action": "confirm", "text": "OK"}],
+		"day_buttons": {"postfix_for_non_selected_day": "🚫"},
+		"unselectable_days": {"before": "2023-06-01", "dates": ["2023-06-12"], "minimum_lead_time": "24h"},
+		"timezone": "Europe/Berlin"
+	}`
+
+	fc, err := LoadFileConfigJSON(strings.NewReader(input))
+	if err != nil {
+		t.Errorf("at LoadFileConfigJSON error: %v", err)
+		return
+	}
+	m, err := NewManagerFromConfig(fc, generator.ChangeYearsForwardForChoose(1))
+	if err != nil {
+		t.Errorf("at NewManagerFromConfig error: %v", err)
+		return
+	}
+
+	got := m.GetCurrentConfig()
+	if got.YearsBackForChoose != 1 || got.YearsForwardForChoose != 1 || got.SumYearsForChoose != 2 {
+		t.Errorf("unexpected years: back %v, forward %v, sum %v", got.YearsBackForChoose, got.YearsForwardForChoose, got.SumYearsForChoose)
+	}
+	if got.DaysNames[0] != "Пн" || got.MonthNames[0] != "Jan" {
+		t.Errorf("unexpected names: days %v, months %v", got.DaysNames, got.MonthNames)
+	}
+	if got.HomeButtonForBeauty != "🏠" || got.HeaderLayout != generator.HeaderLayoutCompact ||
+		got.NumeralSystem != day_button_former.NumeralsPersian {
+		t.Errorf("unexpected view settings: %+v", got)
+	}
+	wantFooter := []generator.FooterButton{
+		{Action: generator.FooterActionToday}, {Action: generator.FooterActionConfirm, Text: "OK"},
+	}
+	if !reflect.DeepEqual(got.FooterButtons, wantFooter) {
+		t.Errorf("unexpected footer: got %v, want %v", got.FooterButtons, wantFooter)
+	}
+	if got.PostfixForNonSelectedDay != "🚫" || got.PostfixForCurrentDay != "🗓" {
+		t.Errorf("unexpected day buttons: %+v", got)
+	}
+	if got.Timezone.String() != "Europe/Berlin" {
+		t.Errorf("unexpected timezone: %v", got.Timezone.String())
+	}
+	if want := time.Date(2023, 6, 1, 0, 0, 0, 0, tzEuropeB); !got.UnselectableDaysBeforeTime.Equal(want) {
+		t.Errorf("unexpected unselectable before: got %v, want %v", got.UnselectableDaysBeforeTime, want)
+	}
+	if len(got.UnselectableDays) != 1 {
+		t.Errorf("unexpected unselectable days: %v", got.UnselectableDays)
+	}
+	wantDay := time.Date(2023, 6, 12, 0, 0, 0, 0, tzEuropeB)
+	for day := range got.UnselectableDays {
+		if !day.Equal(wantDay) {
+			t.Errorf("unexpected unselectable day: got %v, want %v", day, wantDay)
+		}
+	}
+	if got.MinimumLeadTime != 24*time.Hour {
+		t.Errorf("unexpected minimum lead time: %v", got.MinimumLeadTime)
+	}
+
+	// The day is at the config timezone, so it is unavailable.
+	response := m.GenerateCalendarKeyboard("calendar/sed_12.06.2023", time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC))
+	if response.SelectedDay.Location().String() != "Europe/Berlin" {
+		t.Errorf("unexpected selected day location: %v", response.SelectedDay.Location())
+	}
+	keyboard := m.GenerateCalendarKeyboard("", time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)).InlineKeyboardMarkup.InlineKeyboard
+	if text := keyboard[4][0].Text; text != "۱۲🚫" {
+		t.Errorf("unexpected unavailable day text: %v", text)
+	}
+}
+
+func TestLoadFileConfigJSONErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		input     string
+		wantField string
+		wantErr   error
+	}{
+		{name: "unknown field", input: `{"days_name": []}`, wantField: "days_name", wantErr: ErrUnknownField},
+		{name: "wrong type", input: `{"years_back_for_choose": "1"}`, wantField: "years_back_for_choose"},
+		{name: "negative years", input: `{"years_back_for_choose": -1}`, wantField: "years_back_for_choose", wantErr: generator.ErrNegativeValue},
+		{name: "days names count", input: `{"days_names": ["Mo"]}`, wantField: "days_names", wantErr: ErrWrongNamesCount},
+		{name: "month names count", input: `{"month_names": []}`, wantField: "month_names", wantErr: ErrWrongNamesCount},
+		{name: "header layout", input: `{"header_layout": "tiny"}`, wantField: "header_layout", wantErr: generator.ErrUnknownMode},
+		{name: "numeral system", input: `{"numeral_system": "roman"}`, wantField: "numeral_system", wantErr: generator.ErrUnknownMode},
+		{name: "adjacent days mode", input: `{"adjacent_days_mode": "x"}`, wantField: "adjacent_days_mode", wantErr: generator.ErrUnknownMode},
+		{
+			name:      "payload encoder",
+			input:     `{"payload_encoder_decoder": "not registered"}`,
+			wantField: "payload_encoder_decoder",
+			wantErr:   generator.ErrUnknownMode,
+		},
+		{
+			name:      "footer action",
+			input:     `{"footer_buttons": [{"action": "today"}, {"action": "close"}]}`,
+			wantField: "footer_buttons[1].action",
+			wantErr:   generator.ErrUnknownMode,
+		},
+		{name: "timezone", input: `{"timezone": "Mars/Olympus"}`, wantField: "timezone", wantErr: generator.ErrUnknownMode},
+		{name: "empty timezone", input: `{"timezone": ""}`, wantField: "timezone", wantErr: generator.ErrUnknownMode},
+		{name: "utc offset", input: `{"timezone": "UTC+25:00"}`, wantField: "timezone", wantErr: generator.ErrUnknownMode},
+		{
+			name:      "unselectable before",
+			input:     `{"unselectable_days": {"before": "01.06.2023"}}`,
+			wantField: "unselectable_days.before",
+			wantErr:   ErrWrongTime,
+		},
+		{
+			name:      "unselectable date",
+			input:     `{"unselectable_days": {"dates": ["2023-06-01", "2023-06-31"]}}`,
+			wantField: "unselectable_days.dates[1]",
+			wantErr:   ErrWrongTime,
+		},
+		{
+			name:      "minimum lead time",
+			input:     `{"unselectable_days": {"minimum_lead_time": "1 day"}}`,
+			wantField: "unselectable_days.minimum_lead_time",
+			wantErr:   ErrWrongDuration,
+		},
+		{name: "load level", input: `{"load_levels": [{"from": 1.5}]}`, wantField: "load_levels[0].from", wantErr: generator.ErrLoadOutOfRange},
+		{name: "full load threshold", input: `{"full_load_threshold": -1}`, wantField: "full_load_threshold", wantErr: generator.ErrNegativeValue},
+		{name: "empty month name", input: `{"prev_month_name": ""}`, wantField: "prev_month_name", wantErr: generator.ErrEmptyName},
+		{
+			name:      "empty day name",
+			input:     `{"days_names": ["Mo", "", "We", "Th", "Fr", "Sa", "Su"]}`,
+			wantField: "days_names[1]",
+			wantErr:   generator.ErrEmptyName,
+		},
+		{
+			name:      "unselectable range",
+			input:     `{"unselectable_days": {"before": "2023-06-10", "after": "2023-06-01"}}`,
+			wantField: "unselectable_days.after",
+			wantErr:   generator.ErrWrongUnselectableRange,
+		},
+		{
+			name:      "too many years",
+			input:     `{"years_back_for_choose": 10, "years_forward_for_choose": 10}`,
+			wantField: "years_forward_for_choose",
+			wantErr:   generator.ErrTooManyYears,
+		},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, jsonErr := LoadFileConfigJSON(strings.NewReader(tt.input))
+			// JSON is a subset of YAML, the YAML config has the same errors.
+			_, yamlErr := LoadFileConfigYAML(strings.NewReader(tt.input), json.Unmarshal)
+
+			for _, err := range []error{jsonErr, yamlErr} {
+				var fieldErr *FieldError
+				if !errors.As(err, &fieldErr) {
+					t.Errorf("expected field error, got: %v", err)
+					return
+				}
+				if fieldErr.Field != tt.wantField {
+					t.Errorf("unexpected field: got: %v, want: %v", fieldErr.Field, tt.wantField)
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Errorf("unexpected error: got: %v, want: %v", err, tt.wantErr)
+				}
+			}
+		},
+		)
+	}
+}
+
+func TestLoadFileConfigJSONAllValidationErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := LoadFileConfigJSON(strings.NewReader(`{"prev_month_name": "", "next_month_name": ""}`))
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Errorf("expected joined errors, got: %v", err)
+		return
+	}
+
+	var fields []string
+	for _, err := range joined.Unwrap() {
+		var fieldErr *FieldError
+		if !errors.As(err, &fieldErr) {
+			t.Errorf("expected field error, got: %v", err)
+			continue
+		}
+		fields = append(fields, fieldErr.Field)
+	}
+	if want := []string{"prev_month_name", "next_month_name"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("unexpected fields: got: %v, want: %v", fields, want)
+	}
+}
+
+// Unmarshaler like gopkg.in/yaml.v2 one: mappings are map[interface{}]interface{}, unquoted dates are time.Time.
+func yamlV2Unmarshaler(document map[interface{}]interface{}) Unmarshaler {
+	return func(_ []byte, v interface{}) error {
+		*v.(*interface{}) = document
+		return nil
+	}
+}
+
+func TestLoadFileConfigYAMLValues(t *testing.T) {
+	t.Parallel()
+
+	tzEuropeB, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Errorf("at time.LoadLocation for Europe/Berlin error: %v", err)
+		return
+	}
+
+	fc, err := LoadFileConfigYAML(strings.NewReader(""), yamlV2Unmarshaler(map[interface{}]interface{}{
+		"years_back_for_choose": 1,
+		"unselectable_days": map[interface{}]interface{}{
+			// The quoted date, the library keeps it as the string.
+			"before": "2023-06-01",
+			// The midnight UTC time is not a date.
+			"after": time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			"dates": []interface{}{time.Date(2023, 6, 12, 0, 0, 0, 0, time.UTC)},
+		},
+		"timezone": "Europe/Berlin",
+	}))
+	if err != nil {
+		t.Errorf("at LoadFileConfigYAML error: %v", err)
+		return
+	}
+	m, err := NewManagerFromConfig(fc)
+	if err != nil {
+		t.Errorf("at NewManagerFromConfig error: %v", err)
+		return
+	}
+
+	got := m.GetCurrentConfig()
+	if got.YearsBackForChoose != 1 {
+		t.Errorf("unexpected years back: %v", got.YearsBackForChoose)
+	}
+	// The dates are at the config timezone, the times keep their own.
+	if want := time.Date(2023, 6, 1, 0, 0, 0, 0, tzEuropeB); !got.UnselectableDaysBeforeTime.Equal(want) {
+		t.Errorf("unexpected unselectable before: got %v, want %v", got.UnselectableDaysBeforeTime, want)
+	}
+	if want := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC); !got.UnselectableDaysAfterTime.Equal(want) {
+		t.Errorf("unexpected unselectable after: got %v, want %v", got.UnselectableDaysAfterTime, want)
+	}
+	if len(got.UnselectableDays) != 1 {
+		t.Errorf("unexpected unselectable days: %v", got.UnselectableDays)
+	}
+	wantDay := time.Date(2023, 6, 12, 0, 0, 0, 0, tzEuropeB)
+	for day := range got.UnselectableDays {
+		if !day.Equal(wantDay) {
+			t.Errorf("unexpected unselectable day: got %v, want %v", day, wantDay)
+		}
+	}
+
+	_, err = LoadFileConfigYAML(strings.NewReader(""), yamlV2Unmarshaler(map[interface{}]interface{}{1: "one"}))
+	if !errors.Is(err, ErrNotStringKey) {
+		t.Errorf("unexpected error for not string key: %v", err)
+	}
+}
+
+func TestLoadFileConfig(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "calendar.json")
+	yamlPath := filepath.Join(dir, "calendar.yaml")
+	tomlPath := filepath.Join(dir, "calendar.toml")
+	for _, path := range []string{jsonPath, yamlPath, tomlPath} {
+		if err := os.WriteFile(path, []byte(`{"home_button_for_beauty": "🏠"}`), 0o600); err != nil {
+			t.Errorf("at os.WriteFile error: %v", err)
+			return
+		}
+	}
+
+	// JSON is a subset of YAML, so json.Unmarshal stands in for a YAML library here.
+	yamlUnmarshal := Unmarshaler(json.Unmarshal)
+
+	tests := []struct {
+		name          string
+		path          string
+		yamlUnmarshal Unmarshaler
+		wantErr       error
+	}{
+		{name: "json", path: jsonPath},
+		{name: "yaml", path: yamlPath, yamlUnmarshal: yamlUnmarshal},
+		{name: "yaml without unmarshaler", path: yamlPath, wantErr: ErrNoYAMLUnmarshaler},
+		{name: "unknown type", path: tomlPath, yamlUnmarshal: yamlUnmarshal, wantErr: ErrUnknownConfigFileType},
+		{name: "no file", path: filepath.Join(dir, "none.json"), wantErr: os.ErrNotExist},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m, err := NewManagerFromConfigFile(tt.path, tt.yamlUnmarshal)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("unexpected error: got: %v, want: %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && m.GetCurrentConfig().HomeButtonForBeauty != "🏠" {
+				t.Errorf("unexpected home button: %v", m.GetCurrentConfig().HomeButtonForBeauty)
+			}
+		},
+		)
+	}
+}
+
+func TestNewManagerFromConfigValidates(t *testing.T) {
+	t.Parallel()
+
+	fc := DefaultFileConfig()
+	fc.YearsBackForChoose = 5
+	fc.YearsForwardForChoose = 5
+
+	m, err := NewManagerFromConfig(fc)
+	var configErr *generator.ConfigError
+	if m != nil || !errors.As(err, &configErr) || configErr.Field != "SumYearsForChoose" {
+		t.Errorf("unexpected NewManagerFromConfig result: %v, %v", m, err)
+	}
+}
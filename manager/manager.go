@@ -1,24 +1,50 @@
 package manager
 
 import (
+	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/thevan4/telegram-calendar/generator"
 	"github.com/thevan4/telegram-calendar/models"
+	"github.com/thevan4/telegram-calendar/payload_former"
+	"github.com/thevan4/telegram-calendar/tracing"
 )
 
 // KeyboardManager ...
+// GenerateCalendarKeyboard of Manager runs the middlewares (see Manager.Use) and drops their error,
+// the response has IsFailed set then; ContextKeyboardManager returns the error itself.
 type KeyboardManager interface {
 	GenerateCalendarKeyboard(callbackPayload string, currentTime time.Time) models.GenerateCalendarKeyboardResponse
 	ApplyNewOptions(options ...func(generator.KeyboardGenerator) generator.KeyboardGenerator)
 	GetCurrentConfig() FlatConfig
 }
 
+// ContextKeyboardManager KeyboardManager that renders with the context and returns the error of the render,
+// Manager implements it (see Manager.GenerateCalendarKeyboardContext).
+type ContextKeyboardManager interface {
+	KeyboardManager
+	GenerateCalendarKeyboardContext(
+		ctx context.Context,
+		callbackPayload string,
+		currentTime time.Time,
+		renderOptions ...generator.RenderOption,
+	) (models.GenerateCalendarKeyboardResponse, error)
+}
+
 // Manager ...
+// The generator is never changed after it is published: ApplyNewOptions applies the options to a copy
+// and swaps the pointer, so renders take no locks and always see one consistent generator.
 type Manager struct {
-	sync.RWMutex
-	keyboardFormer generator.KeyboardGenerator
+	// Serializes the writers, so concurrent ApplyNewOptions calls do not lose each other's options.
+	writeMu        sync.Mutex
+	keyboardFormer atomic.Pointer[publishedGenerator]
+	metrics        atomic.Pointer[Metrics]
+	tracer         atomic.Pointer[tracing.Tracer]
+	// Middlewares chain, built by Use; nil without middlewares. The middlewares slice is guarded by writeMu.
+	middlewares []Middleware
+	handler     atomic.Pointer[Handler]
 }
 
 // NewManager создает новый экземпляр Manager с настраиваемым KeyboardGenerator.
@@ -27,28 +53,103 @@ func NewManager(options ...func(generator.KeyboardGenerator) generator.KeyboardG
 	defaultManager.ApplyNewOptions(options...)
 	return defaultManager
 }
-func newDefaultManager() *Manager {
-	return &Manager{
-		keyboardFormer: generator.NewKeyboardFormer(),
+
+// NewManagerWithGenerator creates the manager around own generator, a decorator of generator.NewKeyboardFormer
+// for example (see generator.KeyboardGeneratorWrapper).
+func NewManagerWithGenerator(
+	keyboardGenerator generator.KeyboardGenerator,
+	options ...func(generator.KeyboardGenerator) generator.KeyboardGenerator,
+) *Manager {
+	m := &Manager{}
+	m.swapKeyboardGenerator(keyboardGenerator.ApplyNewOptions(options...))
+	return m
+}
+
+// NewManagerE NewManager that validates the resulting settings, see generator.FlatConfig.Validate.
+// The error joins all problems of the settings.
+func NewManagerE(options ...func(generator.KeyboardGenerator) generator.KeyboardGenerator) (*Manager, error) {
+	defaultManager := newDefaultManager()
+	if err := defaultManager.ApplyNewOptionsE(options...); err != nil {
+		return nil, err
 	}
+	return defaultManager, nil
+}
+
+func newDefaultManager() *Manager {
+	m := &Manager{}
+	m.swapKeyboardGenerator(generator.NewKeyboardFormer())
+	return m
+}
+
+// The generator and what is read from its config on every render, so renders do not copy the config.
+type publishedGenerator struct {
+	keyboardFormer generator.KeyboardGenerator
+	// Nil if the generator config has none.
+	payloadDecoder payload_former.PayloadEncoderDecoder
+}
+
+func (m *Manager) keyboardGenerator() generator.KeyboardGenerator {
+	return m.keyboardFormer.Load().keyboardFormer
+}
+
+// Publishes the generator for the renders, writeMu must be held.
+func (m *Manager) publish(keyboardFormer generator.KeyboardGenerator) {
+	m.keyboardFormer.Store(&publishedGenerator{
+		keyboardFormer: keyboardFormer,
+		payloadDecoder: keyboardFormer.GetCurrentConfig().PayloadEncoderDecoder,
+	})
 }
 
 // GenerateCalendarKeyboard ...
+// The middlewares (see Use) are run with context.Background(), on their error the response they returned is returned
+// with IsFailed set, use GenerateCalendarKeyboardContext to get the error.
 func (m *Manager) GenerateCalendarKeyboard(
 	callbackPayload string,
 	currentTime time.Time,
 ) models.GenerateCalendarKeyboardResponse {
-	m.RLock()
-	defer m.RUnlock()
+	return m.GenerateCalendarKeyboardWithOptions(callbackPayload, currentTime)
+}
 
-	return m.keyboardFormer.GenerateCalendarKeyboard(callbackPayload, currentTime)
+// GenerateCalendarKeyboardWithOptions GenerateCalendarKeyboard with the render options (locale, for example),
+// they are applied to this call only and do not change the manager settings.
+func (m *Manager) GenerateCalendarKeyboardWithOptions(
+	callbackPayload string,
+	currentTime time.Time,
+	renderOptions ...generator.RenderOption,
+) models.GenerateCalendarKeyboardResponse {
+	response, _ := m.GenerateCalendarKeyboardContext(context.Background(), callbackPayload, currentTime, renderOptions...)
+	return response
 }
 
 // ApplyNewOptions ...
 func (m *Manager) ApplyNewOptions(options ...func(generator.KeyboardGenerator) generator.KeyboardGenerator) {
-	m.Lock()
-	defer m.Unlock()
-	m.keyboardFormer = m.keyboardFormer.ApplyNewOptions(options...)
+	m.writeMu.Lock()
+	defer m.writeMu.Unlock()
+	m.publish(m.keyboardGenerator().ApplyNewOptions(options...))
+}
+
+// ApplyNewOptionsE ApplyNewOptions that reports the options the generator does not support
+// (see generator.CheckOptions) and validates the resulting settings (see generator.FlatConfig.Validate).
+// On errors the previous settings are kept, the error joins all problems.
+func (m *Manager) ApplyNewOptionsE(options ...func(generator.KeyboardGenerator) generator.KeyboardGenerator) error {
+	m.writeMu.Lock()
+	defer m.writeMu.Unlock()
+	if err := generator.CheckOptions(m.keyboardGenerator(), options...); err != nil {
+		return err
+	}
+	keyboardFormer := m.keyboardGenerator().ApplyNewOptions(options...)
+	if err := keyboardFormer.GetCurrentConfig().Validate(); err != nil {
+		return err
+	}
+	m.publish(keyboardFormer)
+	return nil
+}
+
+// Replaces the generator at once, renders in progress finish with the old one.
+func (m *Manager) swapKeyboardGenerator(keyboardFormer generator.KeyboardGenerator) {
+	m.writeMu.Lock()
+	defer m.writeMu.Unlock()
+	m.publish(keyboardFormer)
 }
 
 // dont want use golang.org/x/exp/maps (added in go versions 1.21).
@@ -62,9 +163,7 @@ func copyMap(src map[time.Time]struct{}) map[time.Time]struct{} {
 
 // GetCurrentConfig ...
 func (m *Manager) GetCurrentConfig() FlatConfig {
-	m.RLock()
-	defer m.RUnlock()
-	keyboardFormerConfig := m.keyboardFormer.GetCurrentConfig()
+	keyboardFormerConfig := m.keyboardGenerator().GetCurrentConfig()
 	return FlatConfig{
 		YearsBackForChoose:         keyboardFormerConfig.YearsBackForChoose,
 		YearsForwardForChoose:      keyboardFormerConfig.YearsForwardForChoose,
@@ -73,15 +172,41 @@ func (m *Manager) GetCurrentConfig() FlatConfig {
 		MonthNames:                 keyboardFormerConfig.MonthNames,
 		HomeButtonForBeauty:        keyboardFormerConfig.HomeButtonForBeauty,
 		PayloadEncoderDecoder:      keyboardFormerConfig.PayloadEncoderDecoder,
+		RightToLeft:                keyboardFormerConfig.RightToLeft,
+		NumeralSystem:              keyboardFormerConfig.NumeralSystem,
+		FooterButtons:              keyboardFormerConfig.FooterButtons,
+		ExtraRowsAbove:             keyboardFormerConfig.ExtraRowsAbove,
+		ExtraRowsBelow:             keyboardFormerConfig.ExtraRowsBelow,
+		HeaderLayout:               keyboardFormerConfig.HeaderLayout,
+		HideHomeButton:             keyboardFormerConfig.HideHomeButton,
+		PrevMonthName:              keyboardFormerConfig.PrevMonthName,
+		NextMonthName:              keyboardFormerConfig.NextMonthName,
+		PrevYearName:               keyboardFormerConfig.PrevYearName,
+		NextYearName:               keyboardFormerConfig.NextYearName,
+		AdjacentDaysMode:           keyboardFormerConfig.AdjacentDaysMode,
+		EventSource:                keyboardFormerConfig.EventSource,
+		EventMarker:                keyboardFormerConfig.EventMarker,
+		ShowEventsCount:            keyboardFormerConfig.ShowEventsCount,
+		FixedWeeksRows:             keyboardFormerConfig.FixedWeeksRows,
+		HideDaysNames:              keyboardFormerConfig.HideDaysNames,
 		PrefixForCurrentDay:        keyboardFormerConfig.PrefixForCurrentDay,
 		PostfixForCurrentDay:       keyboardFormerConfig.PostfixForCurrentDay,
 		PrefixForNonSelectedDay:    keyboardFormerConfig.PrefixForNonSelectedDay,
 		PostfixForNonSelectedDay:   keyboardFormerConfig.PostfixForNonSelectedDay,
 		PrefixForPickDay:           keyboardFormerConfig.PrefixForPickDay,
 		PostfixForPickDay:          keyboardFormerConfig.PostfixForPickDay,
+		PrefixForAdjacentDay:       keyboardFormerConfig.PrefixForAdjacentDay,
+		PostfixForAdjacentDay:      keyboardFormerConfig.PostfixForAdjacentDay,
+		PrefixForPreselectedDay:    keyboardFormerConfig.PrefixForPreselectedDay,
+		PostfixForPreselectedDay:   keyboardFormerConfig.PostfixForPreselectedDay,
 		UnselectableDaysBeforeTime: keyboardFormerConfig.UnselectableDaysBeforeTime,
 		UnselectableDaysAfterTime:  keyboardFormerConfig.UnselectableDaysAfterTime,
-		UnselectableDays:           keyboardFormerConfig.UnselectableDays,
+		UnselectableDays:           copyMap(keyboardFormerConfig.UnselectableDays),
 		Timezone:                   keyboardFormerConfig.Timezone,
+		MinimumLeadTime:            keyboardFormerConfig.MinimumLeadTime,
+		DayDecorators:              keyboardFormerConfig.DayDecorators,
+		LoadProvider:               keyboardFormerConfig.LoadProvider,
+		LoadLevels:                 keyboardFormerConfig.LoadLevels,
+		FullLoadThreshold:          keyboardFormerConfig.FullLoadThreshold,
 	}
 }
@@ -1,6 +1,8 @@
 package manager
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -177,17 +179,29 @@ func TestApplyNewOptions(t *testing.T) {
 		MonthNames:                 [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
 		HomeButtonForBeauty:        "🤡",
 		PayloadEncoderDecoder:      customPayloadEncoderDecoderAtManager{},
+		PrevMonthName:              "<",
+		NextMonthName:              ">",
+		PrevYearName:               "«",
+		NextYearName:               "»",
+		EventMarker:                "•",
+		NumeralSystem:              day_button_former.NumeralsLatin,
 		PrefixForCurrentDay:        "0",
 		PostfixForCurrentDay:       "|",
 		PrefixForNonSelectedDay:    "",
 		PostfixForNonSelectedDay:   "",
 		PrefixForPickDay:           "",
 		PostfixForPickDay:          "",
+		PrefixForAdjacentDay:       "·",
+		PostfixForPreselectedDay:   "✅",
 		UnselectableDaysBeforeTime: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
 		UnselectableDaysAfterTime:  time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
 		UnselectableDays: map[time.Time]struct{}{time.Date(2022,
 			1, 1, 0, 0, 0, 0, time.UTC): {}},
 		Timezone: *time.UTC,
+		LoadLevels: []day_button_former.LoadLevel{
+			{From: 0, Marker: "🟢"}, {From: 0.5, Marker: "🟡"}, {From: 0.75, Marker: "🟠"}, {From: 1, Marker: "🔴"},
+		},
+		FullLoadThreshold: 1,
 	}
 
 	if !reflect.DeepEqual(gotConfig, expectedConfig) {
@@ -366,3 +380,107 @@ func TestGetCurrentConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerateCalendarKeyboardWithLocale(t *testing.T) {
+	t.Parallel()
+
+	m := NewManager()
+	lr := generator.NewLocaleRegistry()
+	currentTime := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	ruKeyboard := m.GenerateCalendarKeyboardWithOptions("", currentTime,
+		generator.WithLanguageCode(lr, "ru")).InlineKeyboardMarkup
+	if ruKeyboard.InlineKeyboard[0][2].Text != "Июн" {
+		t.Errorf("unexpected month name for ru locale: got: %v, want: %v", ruKeyboard.InlineKeyboard[0][2].Text, "Июн")
+	}
+
+	defaultKeyboard := m.GenerateCalendarKeyboard("", currentTime).InlineKeyboardMarkup
+	if defaultKeyboard.InlineKeyboard[0][2].Text != "Jun" {
+		t.Errorf("render options changed manager settings: got: %v, want: %v", defaultKeyboard.InlineKeyboard[0][2].Text, "Jun")
+	}
+}
+
+func TestApplyNewOptionsE(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewManagerE(generator.ChangeYearsBackForChoose(2))
+	if err != nil {
+		t.Errorf("at NewManagerE error: %v", err)
+		return
+	}
+	before := m.GetCurrentConfig()
+
+	err = m.ApplyNewOptionsE(
+		generator.ChangeHomeButtonForBeauty("🏠"),
+		generator.ChangeYearsForwardForChoose(100),
+		generator.ChangeMonthNames([12]string{}),
+	)
+	if !errors.Is(err, generator.ErrTooManyYears) || !errors.Is(err, generator.ErrEmptyName) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if after := m.GetCurrentConfig(); !reflect.DeepEqual(after, before) {
+		t.Errorf("config is changed by wrong options: got %+v, want %+v", after, before)
+	}
+
+	if err := m.ApplyNewOptionsE(generator.ChangeHomeButtonForBeauty("🏠")); err != nil {
+		t.Errorf("at ApplyNewOptionsE error: %v", err)
+	}
+	if home := m.GetCurrentConfig().HomeButtonForBeauty; home != "🏠" {
+		t.Errorf("valid options are not applied: %v", home)
+	}
+
+	m, err = NewManagerE(generator.ChangeYearsBackForChoose(-5))
+	if m != nil || !errors.Is(err, generator.ErrNegativeValue) {
+		t.Errorf("unexpected NewManagerE result: %v, %v", m, err)
+	}
+}
+
+// Own generator the options do not support.
+type opaqueGeneratorAtManager struct {
+	generator.KeyboardGenerator
+}
+
+func (o opaqueGeneratorAtManager) ApplyNewOptions(
+	_ ...func(generator.KeyboardGenerator) generator.KeyboardGenerator,
+) generator.KeyboardGenerator {
+	return o
+}
+
+func TestApplyNewOptionsEReportsUnsupportedOptions(t *testing.T) {
+	t.Parallel()
+
+	m := NewManagerWithGenerator(opaqueGeneratorAtManager{KeyboardGenerator: generator.NewKeyboardFormer()})
+	err := m.ApplyNewOptionsE(generator.ChangeHomeButtonForBeauty("🏠"))
+	var optionErr *generator.UnsupportedOptionError
+	if !errors.As(err, &optionErr) || optionErr.Index != 0 || !errors.Is(err, generator.ErrUnsupportedOption) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGenerateCalendarKeyboardContextCancelled(t *testing.T) {
+	t.Parallel()
+
+	lookups := 0
+	loadProvider := day_button_former.LoadProviderContextFunc(func(_ context.Context, _ time.Time) (float64, bool, error) {
+		lookups++
+		return 1, true, nil
+	})
+	var m ContextKeyboardManager = NewManager(generator.ApplyNewOptionsForButtonsTextWrapper(
+		day_button_former.ChangeLoadProvider(loadProvider),
+	))
+	m.(*Manager).Use(Recovery())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	response, err := m.GenerateCalendarKeyboardContext(ctx, "", time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if lookups != 0 {
+		t.Errorf("load provider is called with the cancelled context: %v", lookups)
+	}
+	// The fallback keyboard: the days with the unknown load are unselectable.
+	if len(response.InlineKeyboardMarkup.InlineKeyboard) == 0 || response.InlineKeyboardMarkup.InlineKeyboard[3][0].Text != "5❌" {
+		t.Errorf("unexpected fallback keyboard: %+v", response.InlineKeyboardMarkup)
+	}
+}
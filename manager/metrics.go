@@ -0,0 +1,82 @@
+package manager
+
+import (
+	"time"
+
+	"github.com/thevan4/telegram-calendar/generator"
+	"github.com/thevan4/telegram-calendar/models"
+)
+
+// Metrics receives an observation of every rendered calendar callback, see SetMetrics.
+// ObserveCallback is called concurrently and must not block the render for long.
+type Metrics interface {
+	ObserveCallback(observation CallbackObservation)
+}
+
+// CallbackObservation what a calendar callback did.
+type CallbackObservation struct {
+	// Readable action name (see generator.ActionName): "open" for the empty payload, "prev_month", "select_day", ...
+	// It is the Action of the response, empty if the payload is not decoded.
+	Action string
+	// The payload is not empty, but the render returned no action (own generators may not set it, then the ones
+	// with a payload decoder are counted here); the default calendar is rendered for such payloads.
+	DecodeFailed bool
+	// Generation time of the response, without the middlewares.
+	RenderDuration time.Duration
+	// A day (or today) is selected and it is available.
+	Selected bool
+	// An unavailable day is tapped.
+	UnselectableDay bool
+	// Calendar days from today to the selected day at the selected day location (the generator timezone
+	// or the user location, see generator.WithSelectedDayInUserLocation), negative for past days.
+	// Set if Selected.
+	DaysFromToday int
+}
+
+// SetMetrics sets the metrics of the callbacks, nil disables them. The metrics are collected after the middlewares,
+// so the callbacks rejected by them are not observed.
+func (m *Manager) SetMetrics(metrics Metrics) {
+	if metrics == nil {
+		m.metrics.Store(nil)
+		return
+	}
+	m.metrics.Store(&metrics)
+}
+
+func newCallbackObservation(
+	published *publishedGenerator,
+	callbackPayload string,
+	currentTime time.Time,
+	response models.GenerateCalendarKeyboardResponse,
+	renderDuration time.Duration,
+) CallbackObservation {
+	observation := CallbackObservation{
+		RenderDuration:  renderDuration,
+		UnselectableDay: response.IsUnselectableDay,
+		Selected:        !response.SelectedDay.IsZero() && !response.IsUnselectableDay,
+	}
+
+	// The action the render decoded, the payload is not decoded twice.
+	switch {
+	case response.Action != "":
+		observation.Action = response.Action
+	case callbackPayload == "":
+		observation.Action, _ = generator.ActionName("")
+	case published.payloadDecoder != nil:
+		observation.DecodeFailed = true
+	}
+
+	if observation.Selected {
+		observation.DaysFromToday = daysBetween(currentTime.In(response.SelectedDay.Location()), response.SelectedDay)
+	}
+	return observation
+}
+
+// Calendar days between the dates of the times, DST changes do not matter.
+func daysBetween(from, to time.Time) int {
+	fromYear, fromMonth, fromDay := from.Date()
+	toYear, toMonth, toDay := to.Date()
+	fromDate := time.Date(fromYear, fromMonth, fromDay, 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(toYear, toMonth, toDay, 0, 0, 0, 0, time.UTC)
+	return int(toDate.Sub(fromDate) / (24 * time.Hour))
+}
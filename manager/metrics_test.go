@@ -0,0 +1,238 @@
+package manager
+
+import (
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/thevan4/telegram-calendar/day_button_former"
+	"github.com/thevan4/telegram-calendar/generator"
+	"github.com/thevan4/telegram-calendar/models"
+	"github.com/thevan4/telegram-calendar/payload_former"
+)
+
+type recordingMetrics struct {
+	mu           sync.Mutex
+	observations []CallbackObservation
+}
+
+func (r *recordingMetrics) ObserveCallback(observation CallbackObservation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.observations = append(r.observations, observation)
+}
+
+func TestMetricsObservations(t *testing.T) {
+	t.Parallel()
+
+	tzEuropeB, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Errorf("at time.LoadLocation for Europe/Berlin error: %v", err)
+		return
+	}
+	m := NewManager(generator.ApplyNewOptionsForButtonsTextWrapper(
+		day_button_former.ChangeTimezone(tzEuropeB),
+		day_button_former.ChangeUnselectableDays(map[time.Time]struct{}{time.Date(2023, 6, 20, 0, 0, 0, 0, tzEuropeB): {}}),
+	))
+	metrics := &recordingMetrics{}
+	m.SetMetrics(metrics)
+
+	// 23:30 UTC is the next day at Berlin.
+	ct := time.Date(2023, 6, 9, 23, 30, 0, 0, time.UTC)
+
+	tzAsiaT, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Errorf("at time.LoadLocation for Asia/Tokyo error: %v", err)
+		return
+	}
+
+	tests := []struct {
+		name            string
+		callbackPayload string
+		// ct if zero.
+		currentTime   time.Time
+		renderOptions []generator.RenderOption
+		want          CallbackObservation
+	}{
+		{name: "open", want: CallbackObservation{Action: "open"}},
+		{name: "navigation", callbackPayload: "calendar/nem_00.06.2023", want: CallbackObservation{Action: "next_month"}},
+		{name: "garbage", callbackPayload: "hello", want: CallbackObservation{DecodeFailed: true}},
+		{name: "unknown action", callbackPayload: "calendar/xyz_01.06.2023", want: CallbackObservation{DecodeFailed: true}},
+		{
+			name:            "selection",
+			callbackPayload: "calendar/sed_12.06.2023",
+			want:            CallbackObservation{Action: "select_day", Selected: true, DaysFromToday: 2},
+		},
+		{
+			name:            "past selection",
+			callbackPayload: "calendar/sed_01.06.2023",
+			want:            CallbackObservation{Action: "select_day", Selected: true, DaysFromToday: -9},
+		},
+		{
+			name:            "unselectable day",
+			callbackPayload: "calendar/uds_20.06.2023",
+			want:            CallbackObservation{Action: "unselectable_day", UnselectableDay: true},
+		},
+		{
+			// 10:00 UTC is the 14th at Berlin and at Tokyo, the selected day is Tokyo midnight of the 15th.
+			name:            "selection at user location",
+			callbackPayload: "calendar/sed_15.03.2026",
+			currentTime:     time.Date(2026, 3, 14, 10, 0, 0, 0, time.UTC),
+			renderOptions:   []generator.RenderOption{generator.WithUserLocation(tzAsiaT), generator.WithSelectedDayInUserLocation()},
+			want:            CallbackObservation{Action: "select_day", Selected: true, DaysFromToday: 1},
+		},
+	}
+
+	// Not parallel, the observations are read by index.
+	for i, tt := range tests {
+		currentTime := ct
+		if !tt.currentTime.IsZero() {
+			currentTime = tt.currentTime
+		}
+		m.GenerateCalendarKeyboardWithOptions(tt.callbackPayload, currentTime, tt.renderOptions...)
+
+		got := metrics.observations[i]
+		if got.RenderDuration <= 0 {
+			t.Errorf("%v: render duration is not set", tt.name)
+		}
+		got.RenderDuration = 0
+		if got != tt.want {
+			t.Errorf("%v: unexpected observation: got %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+
+	m.SetMetrics(nil)
+	m.GenerateCalendarKeyboard("", ct)
+	if len(metrics.observations) != len(tests) {
+		t.Errorf("observed with disabled metrics: %v", len(metrics.observations))
+	}
+}
+
+// Payload encoder decoder that counts the decodings.
+type countingDecoder struct {
+	payload_former.EncoderDecoder
+	decodings *atomic.Int64
+}
+
+func (cd countingDecoder) Decoding(input string) models.PayloadData {
+	cd.decodings.Add(1)
+	return cd.EncoderDecoder.Decoding(input)
+}
+
+func TestMetricsDoNotDecodePayloadAgain(t *testing.T) {
+	t.Parallel()
+
+	decodings := &atomic.Int64{}
+	m := NewManager(generator.ChangePayloadEncoderDecoder(countingDecoder{decodings: decodings}))
+	metrics := &recordingMetrics{}
+	m.SetMetrics(metrics)
+
+	m.GenerateCalendarKeyboard("calendar/nem_00.06.2023", time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC))
+	if decodings.Load() != 1 {
+		t.Errorf("unexpected decodings count: got %v, want 1", decodings.Load())
+	}
+	if metrics.observations[0].Action != "next_month" {
+		t.Errorf("unexpected observation: %+v", metrics.observations[0])
+	}
+}
+
+func TestPrometheusMetrics(t *testing.T) {
+	t.Parallel()
+
+	metrics := NewPrometheusMetrics()
+	metrics.ObserveCallback(CallbackObservation{Action: "open", RenderDuration: 200 * time.Microsecond})
+	metrics.ObserveCallback(CallbackObservation{Action: "next_month", RenderDuration: 2 * time.Millisecond})
+	metrics.ObserveCallback(CallbackObservation{Action: "next_month", RenderDuration: time.Second})
+	metrics.ObserveCallback(CallbackObservation{DecodeFailed: true})
+	metrics.ObserveCallback(CallbackObservation{Action: "select_day", UnselectableDay: true})
+	metrics.ObserveCallback(CallbackObservation{Action: "select_day", Selected: true, DaysFromToday: 5})
+	metrics.ObserveCallback(CallbackObservation{Action: "today", Selected: true})
+
+	recorder := httptest.NewRecorder()
+	metrics.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	if contentType := recorder.Header().Get("Content-Type"); contentType != prometheusContentType {
+		t.Errorf("unexpected content type: %v", contentType)
+	}
+
+	want := `# HELP telegram_calendar_callbacks_total Calendar callbacks by action.
+# TYPE telegram_calendar_callbacks_total counter
+telegram_calendar_callbacks_total{action="next_month"} 2
+telegram_calendar_callbacks_total{action="open"} 1
+telegram_calendar_callbacks_total{action="select_day"} 2
+telegram_calendar_callbacks_total{action="today"} 1
+# HELP telegram_calendar_decode_failures_total Calendar callback payloads that are not decoded.
+# TYPE telegram_calendar_decode_failures_total counter
+telegram_calendar_decode_failures_total 1
+# HELP telegram_calendar_unselectable_day_taps_total Taps on unavailable days.
+# TYPE telegram_calendar_unselectable_day_taps_total counter
+telegram_calendar_unselectable_day_taps_total 1
+# HELP telegram_calendar_render_duration_seconds Calendar keyboard render time.
+# TYPE telegram_calendar_render_duration_seconds histogram
+telegram_calendar_render_duration_seconds_bucket{le="0.0001"} 4
+telegram_calendar_render_duration_seconds_bucket{le="0.00025"} 5
+telegram_calendar_render_duration_seconds_bucket{le="0.0005"} 5
+telegram_calendar_render_duration_seconds_bucket{le="0.001"} 5
+telegram_calendar_render_duration_seconds_bucket{le="0.0025"} 6
+telegram_calendar_render_duration_seconds_bucket{le="0.005"} 6
+telegram_calendar_render_duration_seconds_bucket{le="0.01"} 6
+telegram_calendar_render_duration_seconds_bucket{le="0.025"} 6
+telegram_calendar_render_duration_seconds_bucket{le="0.05"} 6
+telegram_calendar_render_duration_seconds_bucket{le="0.1"} 6
+telegram_calendar_render_duration_seconds_bucket{le="+Inf"} 7
+telegram_calendar_render_duration_seconds_sum 1.0022
+telegram_calendar_render_duration_seconds_count 7
+# HELP telegram_calendar_selected_days_from_today Days from today to the selected day.
+# TYPE telegram_calendar_selected_days_from_today histogram
+telegram_calendar_selected_days_from_today_bucket{le="-30"} 0
+telegram_calendar_selected_days_from_today_bucket{le="-7"} 0
+telegram_calendar_selected_days_from_today_bucket{le="-1"} 0
+telegram_calendar_selected_days_from_today_bucket{le="0"} 1
+telegram_calendar_selected_days_from_today_bucket{le="1"} 1
+telegram_calendar_selected_days_from_today_bucket{le="2"} 1
+telegram_calendar_selected_days_from_today_bucket{le="3"} 1
+telegram_calendar_selected_days_from_today_bucket{le="7"} 2
+telegram_calendar_selected_days_from_today_bucket{le="14"} 2
+telegram_calendar_selected_days_from_today_bucket{le="30"} 2
+telegram_calendar_selected_days_from_today_bucket{le="90"} 2
+telegram_calendar_selected_days_from_today_bucket{le="180"} 2
+telegram_calendar_selected_days_from_today_bucket{le="365"} 2
+telegram_calendar_selected_days_from_today_bucket{le="+Inf"} 2
+telegram_calendar_selected_days_from_today_sum 5
+telegram_calendar_selected_days_from_today_count 2
+`
+	if got := recorder.Body.String(); got != want {
+		t.Errorf("unexpected metrics:\n%v\nwant:\n%v", got, want)
+	}
+}
+
+func TestPrometheusMetricsWithManager(t *testing.T) {
+	t.Parallel()
+
+	metrics := NewPrometheusMetrics()
+	m := NewManager()
+	m.SetMetrics(metrics)
+
+	ct62023 := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	m.GenerateCalendarKeyboard("", ct62023)
+	m.GenerateCalendarKeyboard("calendar/sed_12.06.2023", ct62023)
+
+	var sb strings.Builder
+	if _, err := metrics.WriteTo(&sb); err != nil {
+		t.Errorf("at WriteTo error: %v", err)
+		return
+	}
+	for _, line := range []string{
+		`telegram_calendar_callbacks_total{action="open"} 1`,
+		`telegram_calendar_callbacks_total{action="select_day"} 1`,
+		`telegram_calendar_render_duration_seconds_count 2`,
+		`telegram_calendar_selected_days_from_today_sum 11`,
+	} {
+		if !strings.Contains(sb.String(), line+"\n") {
+			t.Errorf("no %q at metrics:\n%v", line, sb.String())
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package manager
+
+import (
+	"context"
+	"time"
+
+	"github.com/thevan4/telegram-calendar/generator"
+	"github.com/thevan4/telegram-calendar/models"
+	"github.com/thevan4/telegram-calendar/tracing"
+)
+
+// Handler handles one calendar callback. The last handler of the chain renders the keyboard with the manager generator.
+type Handler func(
+	ctx context.Context,
+	callbackPayload string,
+	currentTime time.Time,
+	renderOptions ...generator.RenderOption,
+) (models.GenerateCalendarKeyboardResponse, error)
+
+// Middleware wraps the next handler: it may change the arguments (rewrite the payload, add render options),
+// the response and the error, or return without calling next at all (auth checks, for example).
+type Middleware func(next Handler) Handler
+
+// Use adds the middlewares to the end of the chain. The first added middleware is the outermost one,
+// it is called first and sees the final response.
+func (m *Manager) Use(middlewares ...Middleware) {
+	m.writeMu.Lock()
+	defer m.writeMu.Unlock()
+	m.middlewares = append(m.middlewares[:len(m.middlewares):len(m.middlewares)], middlewares...)
+
+	handler := Handler(m.generate)
+	for i := len(m.middlewares) - 1; i >= 0; i-- {
+		handler = m.middlewares[i](handler)
+	}
+	m.handler.Store(&handler)
+}
+
+// GenerateCalendarKeyboardContext GenerateCalendarKeyboard that runs the middlewares chain (see Use)
+// and returns its error, the response has IsFailed set then. ctx is passed to the providers (see generator.ContextKeyboardGenerator): if it is done,
+// the keyboard is rendered without the lookups that were not made and ctx.Err() is returned with it.
+// The render is traced with the tracer of the manager (see SetTracer) or of ctx.
+func (m *Manager) GenerateCalendarKeyboardContext(
+	ctx context.Context,
+	callbackPayload string,
+	currentTime time.Time,
+	renderOptions ...generator.RenderOption,
+) (models.GenerateCalendarKeyboardResponse, error) {
+	if tracer := m.tracer.Load(); tracer != nil {
+		ctx = tracing.ContextWithTracer(ctx, *tracer)
+	}
+	handler := Handler(m.generate)
+	if chain := m.handler.Load(); chain != nil {
+		handler = *chain
+	}
+	response, err := handler(ctx, callbackPayload, currentTime, renderOptions...)
+	if err != nil {
+		response.IsFailed = true
+	}
+	return response, err
+}
+
+func (m *Manager) generate(
+	ctx context.Context,
+	callbackPayload string,
+	currentTime time.Time,
+	renderOptions ...generator.RenderOption,
+) (models.GenerateCalendarKeyboardResponse, error) {
+	published := m.keyboardFormer.Load()
+	metrics := m.metrics.Load()
+	if metrics == nil {
+		return generator.GenerateCalendarKeyboardContext(ctx, published.keyboardFormer, callbackPayload, currentTime, renderOptions...)
+	}
+
+	start := time.Now()
+	response, err := generator.GenerateCalendarKeyboardContext(ctx, published.keyboardFormer, callbackPayload, currentTime,
+		renderOptions...)
+	(*metrics).ObserveCallback(newCallbackObservation(published, callbackPayload, currentTime, response, time.Since(start)))
+	return response, err
+}
@@ -0,0 +1,232 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thevan4/telegram-calendar/generator"
+	"github.com/thevan4/telegram-calendar/models"
+)
+
+var errNotAllowed = errors.New("not allowed")
+
+type panicGeneratorAtManager struct {
+	generator.KeyboardGenerator
+}
+
+func (p panicGeneratorAtManager) ApplyNewOptions(
+	_ ...func(generator.KeyboardGenerator) generator.KeyboardGenerator,
+) generator.KeyboardGenerator {
+	return p
+}
+
+func (p panicGeneratorAtManager) GenerateCalendarKeyboard(
+	_ string,
+	_ time.Time,
+) models.GenerateCalendarKeyboardResponse {
+	panic("broken generator")
+}
+
+// Records the middleware name before and after the next handler.
+func recordingMiddleware(name string, calls *[]string) Middleware {
+	return func(next Handler) Handler {
+		return func(
+			ctx context.Context,
+			callbackPayload string,
+			currentTime time.Time,
+			renderOptions ...generator.RenderOption,
+		) (models.GenerateCalendarKeyboardResponse, error) {
+			*calls = append(*calls, name+" before")
+			response, err := next(ctx, callbackPayload, currentTime, renderOptions...)
+			*calls = append(*calls, name+" after")
+			return response, err
+		}
+	}
+}
+
+func TestUseOrder(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	m := NewManager()
+	m.Use(recordingMiddleware("first", &calls))
+	m.Use(recordingMiddleware("second", &calls), recordingMiddleware("third", &calls))
+
+	m.GenerateCalendarKeyboard("", time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC))
+
+	want := []string{"first before", "second before", "third before", "third after", "second after", "first after"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("unexpected calls order: got %v, want %v", calls, want)
+	}
+}
+
+func TestMiddlewares(t *testing.T) {
+	t.Parallel()
+
+	ct62023 := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	rewritePayload := func(next Handler) Handler {
+		return func(
+			ctx context.Context,
+			_ string,
+			currentTime time.Time,
+			renderOptions ...generator.RenderOption,
+		) (models.GenerateCalendarKeyboardResponse, error) {
+			return next(ctx, "calendar/sed_12.06.2023", currentTime, renderOptions...)
+		}
+	}
+	denyAll := func(_ Handler) Handler {
+		return func(
+			_ context.Context,
+			_ string,
+			_ time.Time,
+			_ ...generator.RenderOption,
+		) (models.GenerateCalendarKeyboardResponse, error) {
+			return models.GenerateCalendarKeyboardResponse{}, errNotAllowed
+		}
+	}
+
+	tests := []struct {
+		name            string
+		m               *Manager
+		middlewares     []Middleware
+		wantSelectedDay time.Time
+		wantKeyboard    bool
+		wantErr         error
+	}{
+		{
+			name:         "no middlewares",
+			m:            NewManager(),
+			wantKeyboard: true,
+		},
+		{
+			name:            "rewrite payload",
+			m:               NewManager(),
+			middlewares:     []Middleware{rewritePayload},
+			wantSelectedDay: time.Date(2023, 6, 12, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "auth error",
+			m:           NewManager(),
+			middlewares: []Middleware{Recovery(), denyAll},
+			wantErr:     errNotAllowed,
+		},
+		{
+			name:        "recovery",
+			m:           NewManagerWithGenerator(panicGeneratorAtManager{KeyboardGenerator: generator.NewKeyboardFormer()}),
+			middlewares: []Middleware{Recovery()},
+			wantErr:     ErrPanic,
+		},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.m.Use(tt.middlewares...)
+
+			response, err := tt.m.GenerateCalendarKeyboardContext(context.Background(), "", ct62023)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("unexpected error: got: %v, want: %v", err, tt.wantErr)
+				return
+			}
+			if !response.SelectedDay.Equal(tt.wantSelectedDay) {
+				t.Errorf("unexpected selected day: got: %v, want: %v", response.SelectedDay, tt.wantSelectedDay)
+			}
+			if gotKeyboard := len(response.InlineKeyboardMarkup.InlineKeyboard) > 0; gotKeyboard != tt.wantKeyboard {
+				t.Errorf("unexpected keyboard presence: got: %v, want: %v", gotKeyboard, tt.wantKeyboard)
+			}
+			if response.IsFailed != (tt.wantErr != nil) {
+				t.Errorf("unexpected failed flag: got: %v, want: %v", response.IsFailed, tt.wantErr != nil)
+			}
+
+			// GenerateCalendarKeyboard runs the same chain, the error is only flagged.
+			if got := tt.m.GenerateCalendarKeyboard("", ct62023); !reflect.DeepEqual(got, response) {
+				t.Errorf("GenerateCalendarKeyboard response differs: got: %+v, want: %+v", got, response)
+			}
+		},
+		)
+	}
+}
+
+func TestRecoveryPanicError(t *testing.T) {
+	t.Parallel()
+
+	m := NewManagerWithGenerator(panicGeneratorAtManager{KeyboardGenerator: generator.NewKeyboardFormer()})
+	m.Use(Recovery())
+
+	_, err := m.GenerateCalendarKeyboardContext(context.Background(), "", time.Now())
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		t.Errorf("expected panic error, got: %v", err)
+		return
+	}
+	if panicErr.Value != "broken generator" || len(panicErr.Stack) == 0 {
+		t.Errorf("unexpected panic error: %v, stack length %v", panicErr.Value, len(panicErr.Stack))
+	}
+}
+
+func TestLogging(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	denySecond := func(next Handler) Handler {
+		return func(
+			ctx context.Context,
+			callbackPayload string,
+			currentTime time.Time,
+			renderOptions ...generator.RenderOption,
+		) (models.GenerateCalendarKeyboardResponse, error) {
+			if strings.HasSuffix(callbackPayload, "13.06.2023") {
+				return models.GenerateCalendarKeyboardResponse{}, errNotAllowed
+			}
+			return next(ctx, callbackPayload, currentTime, renderOptions...)
+		}
+	}
+	m := NewManager()
+	m.Use(Logging(logger), denySecond)
+
+	ct62023 := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	m.GenerateCalendarKeyboard("calendar/sed_12.06.2023", ct62023)
+	m.GenerateCalendarKeyboard("calendar/sed_13.06.2023", ct62023)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Errorf("unexpected log lines count: %v", lines)
+		return
+	}
+
+	type record struct {
+		Level       string `json:"level"`
+		Msg         string `json:"msg"`
+		Payload     string `json:"payload"`
+		SelectedDay string `json:"selected_day"`
+		Error       string `json:"error"`
+	}
+	want := []record{
+		{Level: "INFO", Msg: "calendar callback", Payload: "calendar/sed_12.06.2023", SelectedDay: "2023-06-12T00:00:00Z"},
+		{Level: "ERROR", Msg: "calendar callback", Payload: "calendar/sed_13.06.2023", Error: errNotAllowed.Error()},
+	}
+	for i, line := range lines {
+		var got record
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Errorf("at json.Unmarshal error: %v", err)
+			return
+		}
+		if got != want[i] {
+			t.Errorf("unexpected log record %v: got %+v, want %+v", i, got, want[i])
+		}
+		if !strings.Contains(line, `"duration":`) {
+			t.Errorf("no duration at log record %v: %v", i, line)
+		}
+	}
+}
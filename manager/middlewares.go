@@ -0,0 +1,86 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"time"
+
+	"github.com/thevan4/telegram-calendar/generator"
+	"github.com/thevan4/telegram-calendar/models"
+)
+
+// ErrPanic ...
+var ErrPanic = errors.New("panic at calendar callback")
+
+// PanicError the panic recovered by Recovery.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("%v: %v", ErrPanic, e.Value)
+}
+
+// Is ...
+func (e *PanicError) Is(target error) bool {
+	return target == ErrPanic
+}
+
+// Recovery turns a panic of the next handlers (a custom generator, an event source, a middleware)
+// into *PanicError and an empty response, so one broken callback does not stop the bot.
+func Recovery() Middleware {
+	return func(next Handler) Handler {
+		return func(
+			ctx context.Context,
+			callbackPayload string,
+			currentTime time.Time,
+			renderOptions ...generator.RenderOption,
+		) (response models.GenerateCalendarKeyboardResponse, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					response = models.GenerateCalendarKeyboardResponse{}
+					err = &PanicError{Value: r, Stack: debug.Stack()}
+				}
+			}()
+			return next(ctx, callbackPayload, currentTime, renderOptions...)
+		}
+	}
+}
+
+// Logging logs every callback to the logger (slog.Default() if nil): the payload, the duration
+// and the selected day at Info level, the error at Error level.
+func Logging(logger *slog.Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(
+			ctx context.Context,
+			callbackPayload string,
+			currentTime time.Time,
+			renderOptions ...generator.RenderOption,
+		) (models.GenerateCalendarKeyboardResponse, error) {
+			l := logger
+			if l == nil {
+				l = slog.Default()
+			}
+
+			start := time.Now()
+			response, err := next(ctx, callbackPayload, currentTime, renderOptions...)
+			attrs := []slog.Attr{
+				slog.String("payload", callbackPayload),
+				slog.Duration("duration", time.Since(start)),
+			}
+			if !response.SelectedDay.IsZero() {
+				attrs = append(attrs, slog.Time("selected_day", response.SelectedDay))
+			}
+			if err != nil {
+				l.LogAttrs(ctx, slog.LevelError, "calendar callback", append(attrs, slog.Any("error", err))...)
+				return response, err
+			}
+			l.LogAttrs(ctx, slog.LevelInfo, "calendar callback", attrs...)
+			return response, nil
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package manager
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+const prometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+//nolint:gochecknoglobals
+var (
+	// RenderDurationBuckets the buckets of telegram_calendar_render_duration_seconds.
+	RenderDurationBuckets = []float64{0.0001, 0.00025, 0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1}
+	// DaysFromTodayBuckets the buckets of telegram_calendar_selected_days_from_today.
+	DaysFromTodayBuckets = []float64{-30, -7, -1, 0, 1, 2, 3, 7, 14, 30, 90, 180, 365}
+)
+
+// PrometheusMetrics Metrics that are exposed in the Prometheus text format, it is the http.Handler of the scrape endpoint:
+//
+//	telegram_calendar_callbacks_total{action="..."} - callbacks by action (see CallbackObservation.Action)
+//	telegram_calendar_decode_failures_total - payloads that are not decoded
+//	telegram_calendar_unselectable_day_taps_total - taps on unavailable days
+//	telegram_calendar_render_duration_seconds - histogram of the render time
+//	telegram_calendar_selected_days_from_today - histogram of the selected days distance from today
+//
+// Taps per selection is the ratio of telegram_calendar_callbacks_total to telegram_calendar_callbacks_total{action="select_day"}.
+type PrometheusMetrics struct {
+	mu               sync.Mutex
+	callbacks        map[string]uint64
+	decodeFailures   uint64
+	unselectableDays uint64
+	renderDuration   histogram
+	daysFromToday    histogram
+}
+
+// NewPrometheusMetrics ...
+func NewPrometheusMetrics() *PrometheusMetrics {
+	return &PrometheusMetrics{
+		callbacks:      make(map[string]uint64),
+		renderDuration: newHistogram(RenderDurationBuckets),
+		daysFromToday:  newHistogram(DaysFromTodayBuckets),
+	}
+}
+
+// ObserveCallback ...
+func (p *PrometheusMetrics) ObserveCallback(observation CallbackObservation) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if observation.DecodeFailed {
+		p.decodeFailures++
+	} else if observation.Action != "" {
+		p.callbacks[observation.Action]++
+	}
+	if observation.UnselectableDay {
+		p.unselectableDays++
+	}
+	p.renderDuration.observe(observation.RenderDuration.Seconds())
+	if observation.Selected {
+		p.daysFromToday.observe(float64(observation.DaysFromToday))
+	}
+}
+
+// ServeHTTP writes the metrics in the Prometheus text format.
+func (p *PrometheusMetrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", prometheusContentType)
+	_, _ = p.WriteTo(w)
+}
+
+// WriteTo writes the metrics in the Prometheus text format.
+func (p *PrometheusMetrics) WriteTo(w io.Writer) (int64, error) {
+	p.mu.Lock()
+	actions := make([]string, 0, len(p.callbacks))
+	for action := range p.callbacks {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	callbacks := make([]uint64, len(actions))
+	for i, action := range actions {
+		callbacks[i] = p.callbacks[action]
+	}
+	decodeFailures, unselectableDays := p.decodeFailures, p.unselectableDays
+	renderDuration, daysFromToday := p.renderDuration.copy(), p.daysFromToday.copy()
+	p.mu.Unlock()
+
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+	writeHeader(cw, "telegram_calendar_callbacks_total", "Calendar callbacks by action.", "counter")
+	for i, action := range actions {
+		fmt.Fprintf(cw, "telegram_calendar_callbacks_total{action=%q} %d\n", action, callbacks[i])
+	}
+	writeHeader(cw, "telegram_calendar_decode_failures_total", "Calendar callback payloads that are not decoded.", "counter")
+	fmt.Fprintf(cw, "telegram_calendar_decode_failures_total %d\n", decodeFailures)
+	writeHeader(cw, "telegram_calendar_unselectable_day_taps_total", "Taps on unavailable days.", "counter")
+	fmt.Fprintf(cw, "telegram_calendar_unselectable_day_taps_total %d\n", unselectableDays)
+	renderDuration.write(cw, "telegram_calendar_render_duration_seconds", "Calendar keyboard render time.")
+	daysFromToday.write(cw, "telegram_calendar_selected_days_from_today", "Days from today to the selected day.")
+
+	if cw.err == nil {
+		cw.err = cw.w.Flush()
+	}
+	return cw.n, cw.err
+}
+
+func writeHeader(w io.Writer, name, help, metricType string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, metricType)
+}
+
+type histogram struct {
+	// Upper bounds of the buckets, sorted; +Inf is implicit.
+	bounds []float64
+	// Not cumulative counts, the last one is +Inf.
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+func newHistogram(bounds []float64) histogram {
+	sorted := append([]float64(nil), bounds...)
+	sort.Float64s(sorted)
+	return histogram{bounds: sorted, counts: make([]uint64, len(sorted)+1)}
+}
+
+func (h *histogram) observe(value float64) {
+	h.counts[sort.SearchFloat64s(h.bounds, value)]++
+	h.sum += value
+	h.count++
+}
+
+func (h *histogram) copy() histogram {
+	c := *h
+	c.counts = append([]uint64(nil), h.counts...)
+	return c
+}
+
+func (h *histogram) write(w io.Writer, name, help string) {
+	writeHeader(w, name, help, "histogram")
+	var cumulative uint64
+	for i, bound := range h.bounds {
+		cumulative += h.counts[i]
+		fmt.Fprintf(w, "%s_bucket{le=%q} %d\n", name, strconv.FormatFloat(bound, 'g', -1, 64), cumulative)
+	}
+	fmt.Fprintf(w, "%s_bucket{le=\"+Inf\"} %d\n", name, h.count)
+	fmt.Fprintf(w, "%s_sum %s\n", name, strconv.FormatFloat(h.sum, 'g', -1, 64))
+	fmt.Fprintf(w, "%s_count %d\n", name, h.count)
+}
+
+// Keeps the first write error, so the writes above do not check every error.
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (cw *countingWriter) Write(b []byte) (int, error) {
+	if cw.err != nil {
+		return 0, cw.err
+	}
+	n, err := cw.w.Write(b)
+	cw.n += int64(n)
+	cw.err = err
+	return n, err
+}
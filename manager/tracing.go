@@ -0,0 +1,13 @@
+package manager
+
+import "github.com/thevan4/telegram-calendar/tracing"
+
+// SetTracer traces the renders with the tracer (see generator.WithContext), nil disables it.
+// The tracer is put into the context of GenerateCalendarKeyboardContext, so the middlewares can use it too.
+func (m *Manager) SetTracer(tracer tracing.Tracer) {
+	if tracer == nil {
+		m.tracer.Store(nil)
+		return
+	}
+	m.tracer.Store(&tracer)
+}
@@ -0,0 +1,93 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/thevan4/telegram-calendar/generator"
+	"github.com/thevan4/telegram-calendar/models"
+	"github.com/thevan4/telegram-calendar/tracing"
+)
+
+func TestTracing(t *testing.T) {
+	t.Parallel()
+
+	ct62023 := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		setTracer      bool
+		contextTracer  bool
+		wantRenderSpan bool
+	}{
+		{name: "manager tracer", setTracer: true, wantRenderSpan: true},
+		{name: "context tracer", contextTracer: true, wantRenderSpan: true},
+		{name: "no tracer"},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			recorder := tracing.NewRecorder()
+			m := NewManager()
+			if tt.setTracer {
+				m.SetTracer(recorder)
+			}
+			ctx := context.Background()
+			if tt.contextTracer {
+				ctx = tracing.ContextWithTracer(ctx, recorder)
+			}
+
+			// The middlewares see the tracer.
+			m.Use(func(next Handler) Handler {
+				return func(
+					ctx context.Context,
+					callbackPayload string,
+					currentTime time.Time,
+					renderOptions ...generator.RenderOption,
+				) (models.GenerateCalendarKeyboardResponse, error) {
+					ctx, span := tracing.Start(ctx, "middleware")
+					defer span.End()
+					return next(ctx, callbackPayload, currentTime, renderOptions...)
+				}
+			})
+
+			if _, err := m.GenerateCalendarKeyboardContext(ctx, "calendar/sed_12.06.2023", ct62023); err != nil {
+				t.Errorf("at GenerateCalendarKeyboardContext error: %v", err)
+				return
+			}
+
+			spans := recorder.Spans()
+			if !tt.wantRenderSpan {
+				if len(spans) != 0 {
+					t.Errorf("unexpected spans: %v", spans)
+				}
+				return
+			}
+			if len(spans) != 3 {
+				t.Errorf("unexpected spans: %v", spans)
+				return
+			}
+			if spans[0].Name != "middleware" || spans[1].Name != generator.SpanRender || spans[1].ParentID != spans[0].ID {
+				t.Errorf("render span is not a child of the middleware span: %+v", spans)
+			}
+		},
+		)
+	}
+}
+
+func TestSetTracerNil(t *testing.T) {
+	t.Parallel()
+
+	recorder := tracing.NewRecorder()
+	m := NewManager()
+	m.SetTracer(recorder)
+	m.SetTracer(nil)
+
+	m.GenerateCalendarKeyboard("", time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC))
+	if len(recorder.Spans()) != 0 {
+		t.Errorf("traced with disabled tracer: %v", recorder.Spans())
+	}
+}
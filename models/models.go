@@ -7,12 +7,59 @@ type InlineKeyboardMarkup struct {
 	InlineKeyboard [][]InlineKeyboardButton `json:"inline_keyboard"`
 }
 
-// InlineKeyboardButton represents one button of an inline keyboard.
+// InlineKeyboardButton https://core.telegram.org/bots/api#inlinekeyboardbutton.
+// Calendar buttons use callback data only, other fields are for the caller's own buttons.
+// Exactly one of the optional fields after the style fields must be set, see Validate.
 type InlineKeyboardButton struct {
-	Text         string `json:"text"`
-	CallbackData string `json:"callback_data,omitempty"`
+	Text string `json:"text"`
+	// Custom emoji shown before the text.
+	IconCustomEmojiID string `json:"icon_custom_emoji_id,omitempty"`
+	// Style of the button, empty means the default one.
+	Style        string      `json:"style,omitempty"`
+	URL          string      `json:"url,omitempty"`
+	CallbackData string      `json:"callback_data,omitempty"`
+	WebApp       *WebAppInfo `json:"web_app,omitempty"`
+	LoginURL     *LoginURL   `json:"login_url,omitempty"`
+	// Empty query is valid (just the bot's username is inserted), so nil means "not set".
+	SwitchInlineQuery *string `json:"switch_inline_query,omitempty"`
+	// Empty query is valid (just the bot's username is inserted), so nil means "not set".
+	SwitchInlineQueryCurrentChat *string                      `json:"switch_inline_query_current_chat,omitempty"`
+	SwitchInlineQueryChosenChat  *SwitchInlineQueryChosenChat `json:"switch_inline_query_chosen_chat,omitempty"`
+	CopyText                     *CopyTextButton              `json:"copy_text,omitempty"`
+	CallbackGame                 *CallbackGame                `json:"callback_game,omitempty"`
+	Pay                          bool                         `json:"pay,omitempty"`
 }
 
+// WebAppInfo https://core.telegram.org/bots/api#webappinfo.
+type WebAppInfo struct {
+	URL string `json:"url"`
+}
+
+// LoginURL https://core.telegram.org/bots/api#loginurl.
+type LoginURL struct {
+	URL                string `json:"url"`
+	ForwardText        string `json:"forward_text,omitempty"`
+	BotUsername        string `json:"bot_username,omitempty"`
+	RequestWriteAccess bool   `json:"request_write_access,omitempty"`
+}
+
+// SwitchInlineQueryChosenChat https://core.telegram.org/bots/api#switchinlinequerychosenchat.
+type SwitchInlineQueryChosenChat struct {
+	Query             string `json:"query,omitempty"`
+	AllowUserChats    bool   `json:"allow_user_chats,omitempty"`
+	AllowBotChats     bool   `json:"allow_bot_chats,omitempty"`
+	AllowGroupChats   bool   `json:"allow_group_chats,omitempty"`
+	AllowChannelChats bool   `json:"allow_channel_chats,omitempty"`
+}
+
+// CopyTextButton https://core.telegram.org/bots/api#copytextbutton.
+type CopyTextButton struct {
+	Text string `json:"text"`
+}
+
+// CallbackGame https://core.telegram.org/bots/api#callbackgame, a placeholder without fields.
+type CallbackGame struct{}
+
 // NewInlineKeyboardButton maker for InlineKeyboardButton.
 func NewInlineKeyboardButton(text, callbackData string) InlineKeyboardButton {
 	return InlineKeyboardButton{
@@ -37,4 +84,35 @@ type GenerateCalendarKeyboardResponse struct {
 	SelectedDay time.Time
 	// selectable date availability flag
 	IsUnselectableDay bool
+	// footer row actions flags
+	IsCleared   bool
+	IsCancelled bool
+	IsConfirmed bool
+	// tapped day with events, it is not a selection
+	EventsDay time.Time
+	Events    []Event
+	// the render returned an error (a middleware or a provider one), the keyboard may be partial or empty
+	IsFailed bool
+	// readable action of the callback payload ("open", "next_month", ..., see generator.ActionName),
+	// empty if the payload is not decoded
+	Action string
+}
+
+// Event is shown as a marker on the calendar day of its start.
+type Event struct {
+	ID    string
+	Title string
+	Start time.Time
+	End   time.Time
+}
+
+// GenerateTimezoneKeyboardResponse timezone picker response.
+type GenerateTimezoneKeyboardResponse struct {
+	// keyboard
+	InlineKeyboardMarkup InlineKeyboardMarkup
+	// selected timezone, nil until the user makes a choice
+	SelectedLocation *time.Location
+	// the chosen zone that is not loaded by time.LoadLocation (no such zone at the host tzdata),
+	// the keyboard is the regions list again
+	UnknownZone string
 }
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"testing"
 )
 
@@ -46,3 +47,92 @@ func TestNewInlineKeyboardButton(t *testing.T) {
 		)
 	}
 }
+
+func TestInlineKeyboardButtonJSON(t *testing.T) {
+	t.Parallel()
+
+	emptyQuery := ""
+	tests := []struct {
+		name   string
+		button InlineKeyboardButton
+		want   string
+	}{
+		{
+			name:   "callback button",
+			button: InlineKeyboardButton{Text: "1", CallbackData: "calendar/sed_01.06.2023"},
+			want:   `{"text":"1","callback_data":"calendar/sed_01.06.2023"}`,
+		},
+		{
+			name:   "url button",
+			button: InlineKeyboardButton{Text: "Site", URL: "https://example.com"},
+			want:   `{"text":"Site","url":"https://example.com"}`,
+		},
+		{
+			name:   "web app button",
+			button: InlineKeyboardButton{Text: "App", WebApp: &WebAppInfo{URL: "https://example.com/app"}},
+			want:   `{"text":"App","web_app":{"url":"https://example.com/app"}}`,
+		},
+		{
+			name:   "switch inline query button with empty query",
+			button: InlineKeyboardButton{Text: "Share", SwitchInlineQuery: &emptyQuery},
+			want:   `{"text":"Share","switch_inline_query":""}`,
+		},
+		{
+			name:   "pay button",
+			button: InlineKeyboardButton{Text: "Pay", Pay: true},
+			want:   `{"text":"Pay","pay":true}`,
+		},
+		{
+			name:   "login url button",
+			button: InlineKeyboardButton{Text: "Login", LoginURL: &LoginURL{URL: "https://example.com/login"}},
+			want:   `{"text":"Login","login_url":{"url":"https://example.com/login"}}`,
+		},
+		{
+			name:   "switch inline query current chat button with empty query",
+			button: InlineKeyboardButton{Text: "Search", SwitchInlineQueryCurrentChat: &emptyQuery},
+			want:   `{"text":"Search","switch_inline_query_current_chat":""}`,
+		},
+		{
+			name: "switch inline query chosen chat button",
+			button: InlineKeyboardButton{
+				Text:                        "Send",
+				SwitchInlineQueryChosenChat: &SwitchInlineQueryChosenChat{Query: "q", AllowUserChats: true},
+			},
+			want: `{"text":"Send","switch_inline_query_chosen_chat":{"query":"q","allow_user_chats":true}}`,
+		},
+		{
+			name:   "copy text button",
+			button: InlineKeyboardButton{Text: "Copy", CopyText: &CopyTextButton{Text: "01.06.2023"}},
+			want:   `{"text":"Copy","copy_text":{"text":"01.06.2023"}}`,
+		},
+		{
+			name:   "callback game button",
+			button: InlineKeyboardButton{Text: "Play", CallbackGame: &CallbackGame{}},
+			want:   `{"text":"Play","callback_game":{}}`,
+		},
+		{
+			name: "styled button with icon",
+			button: InlineKeyboardButton{
+				Text: "1", IconCustomEmojiID: "5368324170671202286", Style: "primary", CallbackData: "calendar/sed_01.06.2023",
+			},
+			want: `{"text":"1","icon_custom_emoji_id":"5368324170671202286","style":"primary",` +
+				`"callback_data":"calendar/sed_01.06.2023"}`,
+		},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := json.Marshal(tt.button)
+			if err != nil {
+				t.Errorf("at json.Marshal error: %v", err)
+				return
+			}
+			if string(got) != tt.want {
+				t.Errorf("unexpected json: got: %s, want: %s", got, tt.want)
+			}
+		},
+		)
+	}
+}
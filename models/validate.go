@@ -0,0 +1,102 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+const (
+	maxButtonTextLen   = 64 // characters.
+	maxCallbackDataLen = 64 // bytes.
+	maxCopyTextLen     = 256
+)
+
+// Telegram constraints errors.
+var (
+	ErrEmptyButtonText        = errors.New("button text is empty")
+	ErrButtonTextLen          = errors.New("button text must be 1-64 characters")
+	ErrNoButtonAction         = errors.New("no optional field is set, exactly one is required")
+	ErrManyButtonActions      = errors.New("more than one optional field is set, exactly one is required")
+	ErrCallbackDataLen        = errors.New("callback data must be 1-64 bytes")
+	ErrCopyTextLen            = errors.New("copy text must be 1-256 characters")
+	ErrWebAppURLEmpty         = errors.New("web app url is empty")
+	ErrLoginURLEmpty          = errors.New("login url is empty")
+	ErrButtonMustBeFirst      = errors.New("pay and callback game buttons must be the first button of the first row")
+	ErrEmptyInlineKeyboardRow = errors.New("inline keyboard row is empty")
+)
+
+// Validate checks the telegram constraints of the button.
+func (b InlineKeyboardButton) Validate() error {
+	if b.Text == "" {
+		return ErrEmptyButtonText
+	}
+	if textLen := utf8.RuneCountInString(b.Text); textLen > maxButtonTextLen {
+		return fmt.Errorf("%w, got %d", ErrButtonTextLen, textLen)
+	}
+
+	switch b.actionsCount() {
+	case 0:
+		return ErrNoButtonAction
+	case 1:
+	default:
+		return ErrManyButtonActions
+	}
+
+	switch {
+	case len(b.CallbackData) > maxCallbackDataLen:
+		return fmt.Errorf("%w, got %d", ErrCallbackDataLen, len(b.CallbackData))
+	case b.CopyText != nil && (b.CopyText.Text == "" || utf8.RuneCountInString(b.CopyText.Text) > maxCopyTextLen):
+		return fmt.Errorf("%w, got %d", ErrCopyTextLen, utf8.RuneCountInString(b.CopyText.Text))
+	case b.WebApp != nil && b.WebApp.URL == "":
+		return ErrWebAppURLEmpty
+	case b.LoginURL != nil && b.LoginURL.URL == "":
+		return ErrLoginURLEmpty
+	}
+
+	return nil
+}
+
+// The number of the optional fields that are set, empty callback data means "not set".
+func (b InlineKeyboardButton) actionsCount() int {
+	isSet := [...]bool{
+		b.URL != "",
+		b.CallbackData != "",
+		b.WebApp != nil,
+		b.LoginURL != nil,
+		b.SwitchInlineQuery != nil,
+		b.SwitchInlineQueryCurrentChat != nil,
+		b.SwitchInlineQueryChosenChat != nil,
+		b.CopyText != nil,
+		b.CallbackGame != nil,
+		b.Pay,
+	}
+
+	count := 0
+	for _, set := range isSet {
+		if set {
+			count++
+		}
+	}
+	return count
+}
+
+// Validate checks every button and the position of pay and callback game buttons.
+// The error contains the row and the button index.
+func (m InlineKeyboardMarkup) Validate() error {
+	for i, row := range m.InlineKeyboard {
+		if len(row) == 0 {
+			return fmt.Errorf("row %d: %w", i, ErrEmptyInlineKeyboardRow)
+		}
+		for j, button := range row {
+			if err := button.Validate(); err != nil {
+				return fmt.Errorf("row %d button %d: %w", i, j, err)
+			}
+			if (button.Pay || button.CallbackGame != nil) && (i != 0 || j != 0) {
+				return fmt.Errorf("row %d button %d: %w", i, j, ErrButtonMustBeFirst)
+			}
+		}
+	}
+
+	return nil
+}
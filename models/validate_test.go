@@ -0,0 +1,141 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestInlineKeyboardButtonValidate(t *testing.T) {
+	t.Parallel()
+
+	emptyQuery := ""
+	tests := []struct {
+		name    string
+		button  InlineKeyboardButton
+		wantErr error
+	}{
+		{
+			name:   "callback button",
+			button: NewInlineKeyboardButton("1", "calendar/sed_01.06.2023"),
+		},
+		{
+			name:   "callback data 64 bytes",
+			button: NewInlineKeyboardButton("1", strings.Repeat("a", 64)),
+		},
+		{
+			name:   "switch inline query with empty query",
+			button: InlineKeyboardButton{Text: "Share", SwitchInlineQuery: &emptyQuery},
+		},
+		{
+			name:   "text 64 characters",
+			button: NewInlineKeyboardButton(strings.Repeat("я", 64), "calendar/sed_01.06.2023"),
+		},
+		{
+			name:    "long text",
+			button:  NewInlineKeyboardButton(strings.Repeat("я", 65), "calendar/sed_01.06.2023"),
+			wantErr: ErrButtonTextLen,
+		},
+		{
+			name:    "empty text",
+			button:  NewInlineKeyboardButton("", "calendar/sed_01.06.2023"),
+			wantErr: ErrEmptyButtonText,
+		},
+		{
+			name:    "no optional field",
+			button:  InlineKeyboardButton{Text: "1"},
+			wantErr: ErrNoButtonAction,
+		},
+		{
+			name:    "two optional fields",
+			button:  InlineKeyboardButton{Text: "1", CallbackData: "calendar/sed_01.06.2023", URL: "https://example.com"},
+			wantErr: ErrManyButtonActions,
+		},
+		{
+			name:    "callback data 65 bytes",
+			button:  NewInlineKeyboardButton("1", strings.Repeat("a", 65)),
+			wantErr: ErrCallbackDataLen,
+		},
+		{
+			name:    "callback data 64 characters but more bytes",
+			button:  NewInlineKeyboardButton("1", strings.Repeat("й", 64)),
+			wantErr: ErrCallbackDataLen,
+		},
+		{
+			name:    "empty copy text",
+			button:  InlineKeyboardButton{Text: "Copy", CopyText: &CopyTextButton{}},
+			wantErr: ErrCopyTextLen,
+		},
+		{
+			name:    "too long copy text",
+			button:  InlineKeyboardButton{Text: "Copy", CopyText: &CopyTextButton{Text: strings.Repeat("й", 257)}},
+			wantErr: ErrCopyTextLen,
+		},
+		{
+			name:    "empty web app url",
+			button:  InlineKeyboardButton{Text: "App", WebApp: &WebAppInfo{}},
+			wantErr: ErrWebAppURLEmpty,
+		},
+		{
+			name:    "empty login url",
+			button:  InlineKeyboardButton{Text: "Login", LoginURL: &LoginURL{}},
+			wantErr: ErrLoginURLEmpty,
+		},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.button.Validate()
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("unexpected error: got: %v, want: %v", err, tt.wantErr)
+			}
+		},
+		)
+	}
+}
+
+func TestInlineKeyboardMarkupValidate(t *testing.T) {
+	t.Parallel()
+
+	payButton := InlineKeyboardButton{Text: "Pay", Pay: true}
+	dayButton := NewInlineKeyboardButton("1", "calendar/sed_01.06.2023")
+	tests := []struct {
+		name     string
+		keyboard [][]InlineKeyboardButton
+		wantErr  error
+	}{
+		{
+			name:     "pay button first",
+			keyboard: [][]InlineKeyboardButton{{payButton, dayButton}, {dayButton}},
+		},
+		{
+			name:     "pay button not first",
+			keyboard: [][]InlineKeyboardButton{{dayButton}, {payButton}},
+			wantErr:  ErrButtonMustBeFirst,
+		},
+		{
+			name:     "empty row",
+			keyboard: [][]InlineKeyboardButton{{dayButton}, {}},
+			wantErr:  ErrEmptyInlineKeyboardRow,
+		},
+		{
+			name:     "invalid button",
+			keyboard: [][]InlineKeyboardButton{{dayButton, {Text: "1"}}},
+			wantErr:  ErrNoButtonAction,
+		},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := InlineKeyboardMarkup{InlineKeyboard: tt.keyboard}.Validate()
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("unexpected error: got: %v, want: %v", err, tt.wantErr)
+			}
+		},
+		)
+	}
+}
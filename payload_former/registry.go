@@ -0,0 +1,81 @@
+package payload_former
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// EncoderDecoderNameDefault name of EncoderDecoder at the registry.
+const EncoderDecoderNameDefault = "default"
+
+// Registry errors.
+var (
+	ErrEmptyEncoderDecoderName = errors.New("encoder decoder name is empty")
+	ErrNilEncoderDecoder       = errors.New("encoder decoder is nil")
+	ErrBuiltInEncoderDecoder   = errors.New("built-in encoder decoder can not be replaced")
+)
+
+// Payload encoders by name, so they can be chosen from config files.
+var encodersRegistry = struct { //nolint:gochecknoglobals
+	sync.RWMutex
+	encoders map[string]PayloadEncoderDecoder
+}{
+	encoders: map[string]PayloadEncoderDecoder{EncoderDecoderNameDefault: NewEncoderDecoder()},
+}
+
+// RegisterEncoderDecoder adds or replaces the encoder decoder by name.
+// EncoderDecoderNameDefault is the built-in one, the default config refers to it and it is not replaced.
+func RegisterEncoderDecoder(name string, encoderDecoder PayloadEncoderDecoder) error {
+	if name == "" {
+		return ErrEmptyEncoderDecoderName
+	}
+	if name == EncoderDecoderNameDefault {
+		return ErrBuiltInEncoderDecoder
+	}
+	if encoderDecoder == nil {
+		return ErrNilEncoderDecoder
+	}
+
+	encodersRegistry.Lock()
+	defer encodersRegistry.Unlock()
+	encodersRegistry.encoders[name] = encoderDecoder
+
+	return nil
+}
+
+// LookupEncoderDecoder finds the registered encoder decoder.
+func LookupEncoderDecoder(name string) (PayloadEncoderDecoder, bool) {
+	encodersRegistry.RLock()
+	defer encodersRegistry.RUnlock()
+	encoderDecoder, ok := encodersRegistry.encoders[name]
+	return encoderDecoder, ok
+}
+
+// LookupEncoderDecoderName finds the name of the registered encoder decoder, used for the config export.
+// Encoders of not comparable types are never found.
+func LookupEncoderDecoderName(encoderDecoder PayloadEncoderDecoder) (string, bool) {
+	if encoderDecoder == nil || !reflect.TypeOf(encoderDecoder).Comparable() {
+		return "", false
+	}
+
+	encodersRegistry.RLock()
+	defer encodersRegistry.RUnlock()
+
+	// Sorted names, so the same encoder registered twice always has the same name.
+	names := make([]string, 0, len(encodersRegistry.encoders))
+	for name := range encodersRegistry.encoders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		registered := encodersRegistry.encoders[name]
+		if reflect.TypeOf(registered) == reflect.TypeOf(encoderDecoder) && registered == encoderDecoder {
+			return name, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,47 @@
+package payload_former
+
+import (
+	"errors"
+	"testing"
+)
+
+type otherEncoderDecoder struct {
+	EncoderDecoder
+	prefix string
+}
+
+func TestEncoderDecoderRegistry(t *testing.T) {
+	t.Parallel()
+
+	if name, ok := LookupEncoderDecoderName(NewEncoderDecoder()); !ok || name != EncoderDecoderNameDefault {
+		t.Errorf("unexpected default encoder name: %v, %v", name, ok)
+	}
+
+	if _, ok := LookupEncoderDecoderName(otherEncoderDecoder{prefix: "not registered"}); ok {
+		t.Errorf("not registered encoder is found")
+	}
+
+	other := otherEncoderDecoder{prefix: "registry test"}
+	if err := RegisterEncoderDecoder("registry test", other); err != nil {
+		t.Errorf("at RegisterEncoderDecoder error: %v", err)
+	}
+	if got, ok := LookupEncoderDecoder("registry test"); !ok || got != other {
+		t.Errorf("unexpected registered encoder: %v, %v", got, ok)
+	}
+	if name, ok := LookupEncoderDecoderName(other); !ok || name != "registry test" {
+		t.Errorf("unexpected registered encoder name: %v, %v", name, ok)
+	}
+
+	if err := RegisterEncoderDecoder("", other); !errors.Is(err, ErrEmptyEncoderDecoderName) {
+		t.Errorf("unexpected error for the empty name: %v", err)
+	}
+	if err := RegisterEncoderDecoder("nil", nil); !errors.Is(err, ErrNilEncoderDecoder) {
+		t.Errorf("unexpected error for the nil encoder: %v", err)
+	}
+	if err := RegisterEncoderDecoder(EncoderDecoderNameDefault, other); !errors.Is(err, ErrBuiltInEncoderDecoder) {
+		t.Errorf("unexpected error for the default name: %v", err)
+	}
+	if got, _ := LookupEncoderDecoder(EncoderDecoderNameDefault); got != NewEncoderDecoder() {
+		t.Errorf("default encoder is replaced: %v", got)
+	}
+}
@@ -0,0 +1,98 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RecordedSpan the span recorded by Recorder.
+type RecordedSpan struct {
+	Name string
+	// IDs are numbered from 1 in the start order, ParentID is 0 for the root spans.
+	ID         int
+	ParentID   int
+	Attributes map[string]any
+	Errors     []error
+	Start      time.Time
+	// Zero if the span is not ended.
+	End time.Time
+}
+
+// Recorder in-memory Tracer for tests, it keeps all the spans.
+type Recorder struct {
+	mu    sync.Mutex
+	spans []*RecordedSpan
+}
+
+// NewRecorder ...
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+type recorderSpanKey struct{}
+
+// Start ...
+func (r *Recorder) Start(ctx context.Context, name string) (context.Context, Span) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	span := &RecordedSpan{Name: name, ID: len(r.spans) + 1, Attributes: make(map[string]any), Start: time.Now()}
+	if parent, ok := ctx.Value(recorderSpanKey{}).(*recorderSpan); ok && parent.recorder == r {
+		span.ParentID = parent.span.ID
+	}
+	r.spans = append(r.spans, span)
+
+	rs := &recorderSpan{recorder: r, span: span}
+	return context.WithValue(ctx, recorderSpanKey{}, rs), rs
+}
+
+// Spans copies of the recorded spans in the start order.
+func (r *Recorder) Spans() []RecordedSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	spans := make([]RecordedSpan, 0, len(r.spans))
+	for _, span := range r.spans {
+		spanCopy := *span
+		spanCopy.Attributes = make(map[string]any, len(span.Attributes))
+		for k, v := range span.Attributes {
+			spanCopy.Attributes[k] = v
+		}
+		spanCopy.Errors = append([]error(nil), span.Errors...)
+		spans = append(spans, spanCopy)
+	}
+	return spans
+}
+
+// Reset forgets the recorded spans.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = nil
+}
+
+type recorderSpan struct {
+	recorder *Recorder
+	span     *RecordedSpan
+}
+
+func (rs *recorderSpan) SetAttribute(key string, value any) {
+	rs.recorder.mu.Lock()
+	defer rs.recorder.mu.Unlock()
+	rs.span.Attributes[key] = value
+}
+
+func (rs *recorderSpan) RecordError(err error) {
+	rs.recorder.mu.Lock()
+	defer rs.recorder.mu.Unlock()
+	rs.span.Errors = append(rs.span.Errors, err)
+}
+
+func (rs *recorderSpan) End() {
+	rs.recorder.mu.Lock()
+	defer rs.recorder.mu.Unlock()
+	if rs.span.End.IsZero() {
+		rs.span.End = time.Now()
+	}
+}
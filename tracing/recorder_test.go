@@ -0,0 +1,74 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+
+	recorder := NewRecorder()
+	ctx, root := Start(ContextWithTracer(context.Background(), recorder), "root")
+	_, child := Start(ctx, "child")
+	child.SetAttribute("key", 1)
+	child.RecordError(errors.New("lookup failed"))
+	child.End()
+	_, sibling := recorder.Start(ctx, "sibling")
+	root.End()
+
+	spans := recorder.Spans()
+	if len(spans) != 3 {
+		t.Errorf("unexpected spans count: %v", spans)
+		return
+	}
+	if spans[0].Name != "root" || spans[0].ID != 1 || spans[0].ParentID != 0 || spans[0].End.IsZero() {
+		t.Errorf("unexpected root span: %+v", spans[0])
+	}
+	if spans[1].Name != "child" || spans[1].ParentID != 1 || spans[1].Attributes["key"] != 1 || len(spans[1].Errors) != 1 {
+		t.Errorf("unexpected child span: %+v", spans[1])
+	}
+	if spans[2].Name != "sibling" || spans[2].ParentID != 1 || !spans[2].End.IsZero() {
+		t.Errorf("unexpected sibling span: %+v", spans[2])
+	}
+
+	// The spans are copies.
+	spans[1].Attributes["key"] = 2
+	sibling.End()
+	if spans = recorder.Spans(); spans[1].Attributes["key"] != 1 || spans[2].End.IsZero() {
+		t.Errorf("recorded spans are changed through the copies: %+v", spans)
+	}
+
+	recorder.Reset()
+	if len(recorder.Spans()) != 0 {
+		t.Errorf("spans are not reset: %v", recorder.Spans())
+	}
+}
+
+func TestTracerFromContext(t *testing.T) {
+	t.Parallel()
+
+	recorder := NewRecorder()
+	tests := []struct {
+		name string
+		ctx  context.Context //nolint:containedctx // test case.
+		want Tracer
+	}{
+		{name: "nil context", want: NoopTracer{}},
+		{name: "no tracer", ctx: context.Background(), want: NoopTracer{}},
+		{name: "nil tracer", ctx: ContextWithTracer(context.Background(), nil), want: NoopTracer{}},
+		{name: "tracer", ctx: ContextWithTracer(context.Background(), recorder), want: recorder},
+	}
+
+	for _, tmpTT := range tests {
+		tt := tmpTT
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := TracerFromContext(tt.ctx); got != tt.want {
+				t.Errorf("TracerFromContext() = %v, want %v", got, tt.want)
+			}
+		},
+		)
+	}
+}
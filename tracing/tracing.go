@@ -0,0 +1,67 @@
+package tracing
+
+import "context"
+
+// Tracer starts spans, the OpenTelemetry tracer is easy to adapt to it.
+// The span is a child of the span at ctx, the returned context holds the new span.
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span ...
+type Span interface {
+	SetAttribute(key string, value any)
+	RecordError(err error)
+	End()
+}
+
+// TracerFunc adapter to use a function as Tracer.
+type TracerFunc func(ctx context.Context, name string) (context.Context, Span)
+
+// Start calls f(ctx, name).
+func (f TracerFunc) Start(ctx context.Context, name string) (context.Context, Span) {
+	return f(ctx, name)
+}
+
+// NoopTracer the tracer that does nothing, the default one.
+type NoopTracer struct{}
+
+// Start returns ctx as is and the span that does nothing.
+func (NoopTracer) Start(ctx context.Context, _ string) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(_ string, _ any) {}
+func (noopSpan) RecordError(_ error)          {}
+func (noopSpan) End()                         {}
+
+type tracerKey struct{}
+
+// ContextWithTracer returns a copy of ctx with the tracer, nil tracer is NoopTracer.
+func ContextWithTracer(ctx context.Context, tracer Tracer) context.Context {
+	if tracer == nil {
+		tracer = NoopTracer{}
+	}
+	return context.WithValue(ctx, tracerKey{}, tracer)
+}
+
+// TracerFromContext the tracer of ctx, NoopTracer if there is none (or ctx is nil).
+func TracerFromContext(ctx context.Context) Tracer {
+	if ctx == nil {
+		return NoopTracer{}
+	}
+	if tracer, ok := ctx.Value(tracerKey{}).(Tracer); ok {
+		return tracer
+	}
+	return NoopTracer{}
+}
+
+// Start starts the span with the tracer of ctx.
+func Start(ctx context.Context, name string) (context.Context, Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return TracerFromContext(ctx).Start(ctx, name)
+}